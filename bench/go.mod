@@ -0,0 +1,17 @@
+module bench
+
+go 1.23.4
+
+require (
+	github.com/alexflint/go-arg v1.5.1
+	github.com/samber/lo v1.47.0
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
+	github.com/alexflint/go-scalar v1.2.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
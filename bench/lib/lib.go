@@ -0,0 +1,185 @@
+package lib
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// Result is what a puzzle's benchmark measured for a single solve.
+type Result struct {
+	NsPerOp     float64 `json:"nsPerOp"`
+	BytesPerOp  int64   `json:"bytesPerOp"`
+	AllocsPerOp int64   `json:"allocsPerOp"`
+}
+
+// Run records one pass of benchmarks over the puzzles, keyed by puzzle name, e.g. `day-07/puzzle-b`.
+type Run struct {
+	Label   string            `json:"label"`
+	Time    time.Time         `json:"time"`
+	Results map[string]Result `json:"results"`
+}
+
+type History struct {
+	Runs []Run `json:"runs"`
+}
+
+// ReadHistory reads the history file, which is taken to be empty when it does not exist yet.
+func ReadHistory(path string) (History, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return History{}, nil
+	}
+	if err != nil {
+		return History{}, err //nolint:wrapcheck // Toy code
+	}
+
+	var history History
+	err = json.Unmarshal(data, &history)
+	if err != nil {
+		return History{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return history, nil
+}
+
+// WriteHistory replaces the history file, going through a temporary file so that an interrupted write leaves the old
+// history in place.
+func WriteHistory(path string, history History) error {
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	tempPath := path + ".tmp"
+	err = os.WriteFile(tempPath, append(data, '\n'), 0o644) //nolint:gosec,mnd // Meant to be shared
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	return os.Rename(tempPath, path) //nolint:wrapcheck // Toy code
+}
+
+// Baseline returns the latest run before the last one, restricted to runs with the given label unless it is empty.
+func (h History) Baseline(label string) (Run, bool) {
+	for iRun := len(h.Runs) - 2; iRun >= 0; iRun-- {
+		if label == "" || h.Runs[iRun].Label == label {
+			return h.Runs[iRun], true
+		}
+	}
+
+	return Run{}, false
+}
+
+// FindPuzzles lists the puzzles under the repository root that have a benchmark, as a map from puzzle name to the
+// directory of its module.
+func FindPuzzles(root string) (map[string]string, error) {
+	puzzles := make(map[string]string)
+	for _, pattern := range []string{"day-*/puzzle-*/main_test.go", "day-*/puzzle-*/src/main_test.go"} {
+		paths, err := filepath.Glob(filepath.Join(root, pattern))
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Toy code
+		}
+		for _, path := range paths {
+			dir := filepath.Dir(path)
+			name, err := filepath.Rel(root, strings.TrimSuffix(dir, string(filepath.Separator)+"src"))
+			if err != nil {
+				return nil, err //nolint:wrapcheck // Toy code
+			}
+			puzzles[filepath.ToSlash(name)] = dir
+		}
+	}
+
+	return puzzles, nil
+}
+
+// ParseBenchmarkOutput collects the results from the output of `go test -bench -benchmem`, keyed by benchmark name
+// without its GOMAXPROCS suffix. Lines other than benchmark results are skipped.
+func ParseBenchmarkOutput(r io.Reader) (map[string]Result, error) {
+	results := make(map[string]Result)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") { //nolint:mnd // Name, count, value, unit
+			continue
+		}
+		if _, err := strconv.Atoi(fields[1]); err != nil {
+			continue
+		}
+
+		name := fields[0]
+		if iDash := strings.LastIndexByte(name, '-'); iDash >= 0 {
+			if _, err := strconv.Atoi(name[iDash+1:]); err == nil {
+				name = name[:iDash]
+			}
+		}
+
+		var result Result
+		for iField := 2; iField+1 < len(fields); iField += 2 {
+			value, err := strconv.ParseFloat(fields[iField], 64)
+			if err != nil {
+				return nil, fmt.Errorf("benchmark %s: invalid value %q", name, fields[iField])
+			}
+			switch fields[iField+1] {
+			case "ns/op":
+				result.NsPerOp = value
+			case "B/op":
+				result.BytesPerOp = int64(value)
+			case "allocs/op":
+				result.AllocsPerOp = int64(value)
+			}
+		}
+		results[name] = result
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+
+	return results, nil
+}
+
+// Row compares a puzzle's last result against its baseline; either is nil when the puzzle is missing from that run.
+// Change is the relative change in time per solve, in percent.
+type Row struct {
+	Puzzle    string
+	Baseline  *Result
+	Last      *Result
+	Change    float64
+	Regressed bool
+}
+
+// Compare lines up the puzzles of both runs, flagging those that got slower by more than threshold percent.
+func Compare(baseline, last Run, threshold float64) []Row {
+	puzzles := lo.Union(lo.Keys(baseline.Results), lo.Keys(last.Results))
+	slices.Sort(puzzles)
+
+	rows := make([]Row, 0, len(puzzles))
+	for _, puzzle := range puzzles {
+		row := Row{Puzzle: puzzle, Change: math.NaN()}
+		if result, ok := baseline.Results[puzzle]; ok {
+			row.Baseline = &result
+		}
+		if result, ok := last.Results[puzzle]; ok {
+			row.Last = &result
+		}
+		if row.Baseline != nil && row.Last != nil && row.Baseline.NsPerOp > 0 {
+			row.Change = (row.Last.NsPerOp - row.Baseline.NsPerOp) / row.Baseline.NsPerOp * 100 //nolint:mnd // Percent
+			row.Regressed = row.Change > threshold
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
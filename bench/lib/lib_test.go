@@ -0,0 +1,87 @@
+package lib
+
+import (
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBenchmarkOutput(t *testing.T) {
+	output := `goos: linux
+goarch: amd64
+pkg: dayseven
+BenchmarkSolve-8   	       3	 348216141 ns/op	28023536 B/op	 1350204 allocs/op
+BenchmarkOther 	     100	     12.5 ns/op
+PASS
+ok  	dayseven	1.742s
+`
+	results, err := ParseBenchmarkOutput(strings.NewReader(output))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Result{
+		"BenchmarkSolve": {NsPerOp: 348216141, BytesPerOp: 28023536, AllocsPerOp: 1350204},
+		"BenchmarkOther": {NsPerOp: 12.5},
+	}, results)
+}
+
+func TestCompare(t *testing.T) {
+	baseline := Run{Results: map[string]Result{
+		"day-01/puzzle-a": {NsPerOp: 100},
+		"day-01/puzzle-b": {NsPerOp: 100},
+		"day-02/puzzle-a": {NsPerOp: 100},
+	}}
+	last := Run{Results: map[string]Result{
+		"day-01/puzzle-a": {NsPerOp: 105},
+		"day-01/puzzle-b": {NsPerOp: 150},
+		"day-03/puzzle-a": {NsPerOp: 100},
+	}}
+
+	rows := Compare(baseline, last, 10)
+	require.Len(t, rows, 4)
+	assert.Equal(t, "day-01/puzzle-a", rows[0].Puzzle)
+	assert.InDelta(t, 5, rows[0].Change, 1e-9)
+	assert.False(t, rows[0].Regressed)
+	assert.InDelta(t, 50, rows[1].Change, 1e-9)
+	assert.True(t, rows[1].Regressed)
+	assert.Nil(t, rows[2].Last)
+	assert.True(t, math.IsNaN(rows[2].Change))
+	assert.Nil(t, rows[3].Baseline)
+	assert.False(t, rows[3].Regressed)
+}
+
+func TestBaseline(t *testing.T) {
+	history := History{Runs: []Run{{Label: "v1"}, {Label: "v2"}, {Label: "v1"}, {Label: "v3"}}}
+
+	run, ok := history.Baseline("")
+	require.True(t, ok)
+	assert.Equal(t, "v1", run.Label)
+	_, ok = history.Baseline("v3")
+	assert.False(t, ok)
+	run, ok = history.Baseline("v2")
+	require.True(t, ok)
+	assert.Equal(t, "v2", run.Label)
+	_, ok = History{Runs: []Run{{Label: "v1"}}}.Baseline("")
+	assert.False(t, ok)
+}
+
+func TestHistoryRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	history, err := ReadHistory(path)
+	require.NoError(t, err)
+	assert.Empty(t, history.Runs)
+
+	history.Runs = append(history.Runs, Run{
+		Label:   "abc1234",
+		Time:    time.Date(2024, time.December, 25, 6, 0, 0, 0, time.UTC),
+		Results: map[string]Result{"day-25/puzzle-a": {NsPerOp: 1.5e6, BytesPerOp: 1024, AllocsPerOp: 7}},
+	})
+	require.NoError(t, WriteHistory(path, history))
+
+	reread, err := ReadHistory(path)
+	require.NoError(t, err)
+	assert.Equal(t, history, reread)
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"bench/lib"
+
+	"github.com/alexflint/go-arg"
+	"github.com/samber/lo"
+)
+
+type Args struct {
+	Root      string  `arg:"--root"      default:".."           help:"repository root holding the day-NN directories"`
+	History   string  `arg:"--history"   default:"history.json" help:"JSON file recording every benchmark run"`
+	Label     string  `arg:"--label"                            help:"label to record the run under (defaults to the current commit)"`
+	Baseline  string  `arg:"--baseline"                         help:"label of the run to compare against (defaults to the run before the last)"`
+	Threshold float64 `arg:"--threshold" default:"10"           help:"percentage by which a puzzle may slow down before it is flagged"`
+	Filter    string  `arg:"--filter"                           help:"only benchmark and report puzzles matching this regexp, e.g. day-1[89]"`
+	BenchTime string  `arg:"--benchtime"                        help:"benchmark time passed on to go test, e.g. 1x or 5s"`
+	Timeout   string  `arg:"--timeout"   default:"60m"          help:"timeout passed on to go test for every puzzle"`
+	NoRun     bool    `arg:"--no-run"                           help:"only print the table for the runs already recorded"`
+}
+
+func main() {
+	var args Args
+	arg.MustParse(&args)
+
+	filter, err := regexp.Compile(args.Filter)
+	if err != nil {
+		log.Fatal(err) //nolint:revive // Toy code
+	}
+
+	history, err := lib.ReadHistory(args.History)
+	if err != nil {
+		log.Fatal(err) //nolint:revive // Toy code
+	}
+
+	if !args.NoRun {
+		run := runBenchmarks(args, filter)
+		history.Runs = append(history.Runs, run)
+		err = lib.WriteHistory(args.History, history)
+		if err != nil {
+			log.Fatal(err) //nolint:revive // Toy code
+		}
+	}
+	if len(history.Runs) < 1 {
+		log.Fatalf("no runs recorded in %s", args.History) //nolint:revive // Toy code
+	}
+
+	last := history.Runs[len(history.Runs)-1]
+	baseline, ok := history.Baseline(args.Baseline)
+	if !ok && args.Baseline != "" {
+		log.Fatalf("no earlier run labelled %q in %s", args.Baseline, args.History) //nolint:revive // Toy code
+	}
+
+	rows := lo.Filter(lib.Compare(baseline, last, args.Threshold), func(row lib.Row, _ int) bool {
+		return filter.MatchString(row.Puzzle)
+	})
+	err = writeTable(os.Stdout, rows, baseline, last)
+	if err != nil {
+		log.Fatal(err) //nolint:revive // Toy code
+	}
+
+	regressions := lo.CountBy(rows, func(row lib.Row) bool { return row.Regressed })
+	if regressions > 0 {
+		log.Printf("%d puzzle(s) slowed down by more than %g%%", regressions, args.Threshold)
+		os.Exit(1) //nolint:revive // Toy code
+	}
+}
+
+// runBenchmarks runs the benchmark of every puzzle matching the filter, one module at a time. Puzzles whose benchmark
+// fails are left out of the run.
+func runBenchmarks(args Args, filter *regexp.Regexp) lib.Run {
+	puzzles, err := lib.FindPuzzles(args.Root)
+	if err != nil {
+		log.Fatal(err) //nolint:revive // Toy code
+	}
+	names := lo.Filter(lo.Keys(puzzles), func(name string, _ int) bool { return filter.MatchString(name) })
+	slices.Sort(names)
+	if len(names) < 1 {
+		log.Fatalf("no puzzle benchmarks found under %s", args.Root) //nolint:revive // Toy code
+	}
+
+	label := args.Label
+	if label == "" {
+		label = currentCommit(args.Root)
+	}
+	run := lib.Run{Label: label, Time: time.Now().UTC(), Results: make(map[string]lib.Result)}
+	for _, name := range names {
+		log.Printf("benchmarking %s", name)
+		result, err := benchmarkPuzzle(puzzles[name], args)
+		if err != nil {
+			log.Printf("%s: %v", name, err)
+			continue
+		}
+		run.Results[name] = result
+	}
+
+	return run
+}
+
+func benchmarkPuzzle(dir string, args Args) (lib.Result, error) {
+	goArgs := []string{"test", "-run", "^$", "-bench", "^BenchmarkSolve$", "-benchmem", "-timeout", args.Timeout}
+	if args.BenchTime != "" {
+		goArgs = append(goArgs, "-benchtime", args.BenchTime)
+	}
+	cmd := exec.Command("go", append(goArgs, ".")...)
+	cmd.Dir = dir
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+	if err != nil {
+		return lib.Result{}, fmt.Errorf("go test: %w\n%s", err, strings.TrimSpace(output.String()))
+	}
+
+	results, err := lib.ParseBenchmarkOutput(&output)
+	if err != nil {
+		return lib.Result{}, err //nolint:wrapcheck // Toy code
+	}
+	result, ok := results["BenchmarkSolve"]
+	if !ok {
+		return lib.Result{}, fmt.Errorf("no BenchmarkSolve result in the go test output")
+	}
+
+	return result, nil
+}
+
+// currentCommit names the commit checked out in the repository, falling back to no label outside of git.
+func currentCommit(root string) string {
+	cmd := exec.Command("git", "rev-parse", "--short", "HEAD")
+	cmd.Dir = root
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(output))
+}
+
+func writeTable(w io.Writer, rows []lib.Row, baseline, last lib.Run) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight) //nolint:mnd // Column padding
+	fmt.Fprintf(tw, "puzzle\tbaseline %s\tlast %s\tchange\tallocs/op\t\n", runName(baseline), runName(last))
+	for _, row := range rows {
+		change := "-"
+		if !math.IsNaN(row.Change) {
+			change = fmt.Sprintf("%+.1f%%", row.Change)
+		}
+		if row.Regressed {
+			change += " SLOWER"
+		}
+		allocs := "-"
+		if row.Last != nil {
+			allocs = fmt.Sprint(row.Last.AllocsPerOp)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t\n", row.Puzzle, formatTime(row.Baseline), formatTime(row.Last), change, allocs)
+	}
+
+	return tw.Flush() //nolint:wrapcheck // Toy code
+}
+
+func runName(run lib.Run) string {
+	if run.Time.IsZero() {
+		return "(none)"
+	}
+	if run.Label == "" {
+		return run.Time.Format(time.DateTime)
+	}
+
+	return run.Label
+}
+
+// formatTime shows the time per solve rounded to about four significant digits.
+func formatTime(result *lib.Result) string {
+	if result == nil {
+		return "-"
+	}
+
+	duration := time.Duration(result.NsPerOp)
+	switch {
+	case duration >= time.Second:
+		duration = duration.Round(time.Millisecond)
+	case duration >= time.Millisecond:
+		duration = duration.Round(time.Microsecond)
+	}
+
+	return duration.String()
+}
@@ -0,0 +1,142 @@
+// Package command is the command line of every puzzle: it parses the solver's Args, sets up logging and profiling,
+// finds the input, and writes the report, so that each puzzle's main only names its day, examples and solver.
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"strings"
+
+	"common/examples"
+	"common/input"
+	"common/logging"
+	"common/parsing"
+	"common/profiling"
+	"common/report"
+
+	"github.com/alexflint/go-arg"
+)
+
+// Flags is embedded first in every solver's Args, so that the input file is the first positional argument, before any
+// the puzzle takes.
+type Flags struct {
+	InputFile string        `arg:"positional" help:"input file (default: the cached input of the day)"`
+	Output    report.Format `arg:"--output"   default:"text" help:"output format: text, or json for one object with the answers on stdout"`
+
+	logging.Flags
+	examples.Flag
+	profiling.Files
+}
+
+// Command gives the command the flags embedded in a solver's Args.
+func (f *Flags) Command() *Flags {
+	return f
+}
+
+// Args is a pointer to a solver's Args, which embed Flags.
+type Args[A any] interface {
+	*A
+	Command() *Flags
+}
+
+// InputNeeder is implemented by the Args of a solver that can do without an input for some options, e.g. explaining how
+// to type a code given on the command line. When it needs none and no input file is given, the solver reads an empty
+// input.
+type InputNeeder interface {
+	NeedsInput() bool
+}
+
+// ErrExampleFailed is returned when an example is solved but its answers are not the expected ones.
+var ErrExampleFailed = errors.New("example failed")
+
+// Main runs the puzzle on the command line and exits with status 1 when it fails. Parse errors in the input file are
+// printed compiler style, as file:line:col: message.
+func Main[A any, P Args[A]](
+	day int, exampleFS fs.FS, solve func(context.Context, A, io.Reader, *report.Report) error,
+) {
+	args := P(new(A))
+	arg.MustParse(args)
+	flags := args.Command()
+
+	err := Run(context.Background(), day, exampleFS, solve, args, os.Args[1:], os.Stdout)
+	var parseErr *parsing.Error
+	switch {
+	case err == nil:
+	case flags.Example == 0 && errors.As(err, &parseErr):
+		fmt.Fprintln(os.Stderr, parsing.Diagnostic(flags.InputFile, err))
+		os.Exit(1) //nolint:revive // Toy code
+	case errors.Is(err, ErrExampleFailed):
+		os.Exit(1) //nolint:revive // Toy code
+	default:
+		log.Fatal(err) //nolint:revive // Toy code
+	}
+}
+
+// Run solves the example asked for with --example, or else the input file, defaulting to the cached input of the day,
+// and writes the report to stdout. args were parsed from the command line, which an example's options are parsed
+// again with. The report of an example is written even when its answers are wrong.
+func Run[A any, P Args[A]](
+	ctx context.Context, day int, exampleFS fs.FS, solve func(context.Context, A, io.Reader, *report.Report) error,
+	args P, commandLine []string, stdout io.Writer,
+) error {
+	flags := args.Command()
+	logging.Setup(flags.Flags)
+	stop, err := profiling.Start(flags.Files)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+	defer stop()
+
+	out := report.New(flags.Output)
+	if flags.Example > 0 {
+		passed, err := examples.Run(ctx, exampleFS, flags.Example, commandLine, solve, out)
+		if err != nil {
+			return err //nolint:wrapcheck // Toy code
+		}
+		err = out.Write(stdout)
+		if err != nil {
+			return err //nolint:wrapcheck // Toy code
+		}
+		if !passed {
+			return ErrExampleFailed
+		}
+		return nil
+	}
+
+	if needer, ok := any(*args).(InputNeeder); !ok || needer.NeedsInput() {
+		flags.InputFile, err = input.Resolve(flags.InputFile, day)
+		if err != nil {
+			return err //nolint:wrapcheck // Toy code
+		}
+	}
+	err = solveInputFile(ctx, *args, flags.InputFile, solve, out)
+	if err != nil {
+		return err
+	}
+
+	return out.Write(stdout) //nolint:wrapcheck // Toy code
+}
+
+func solveInputFile[A any](
+	ctx context.Context, args A, inputFile string, solve func(context.Context, A, io.Reader, *report.Report) error,
+	out *report.Report,
+) (err error) {
+	if inputFile == "" {
+		return solve(ctx, args, strings.NewReader(""), out)
+	}
+
+	file, err := os.Open(inputFile)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	return solve(ctx, args, file, out)
+}
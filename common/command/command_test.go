@@ -0,0 +1,162 @@
+package command
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"testing/fstest"
+
+	"common/input"
+	"common/parsing"
+	"common/report"
+
+	"github.com/alexflint/go-arg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeArgs struct {
+	Flags
+
+	Factor int `arg:"-f" default:"1"`
+}
+
+type fakeExplainArgs struct {
+	Flags
+
+	Explain bool `arg:"--explain"`
+}
+
+func (a fakeExplainArgs) NeedsInput() bool {
+	return !a.Explain
+}
+
+// fakeSolve sums the numbers of the input, one per line, times the factor.
+func fakeSolve(_ context.Context, args fakeArgs, input io.Reader, out *report.Report) error {
+	scanner := bufio.NewScanner(input)
+	sum := 0
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		num, err := strconv.Atoi(scanner.Text())
+		if err != nil {
+			return parsing.Errorf(lineNum, 1, scanner.Text(), "expected a number")
+		}
+		sum += num
+	}
+	out.Answer("sum", sum*args.Factor)
+
+	return scanner.Err()
+}
+
+func fakeExplain(_ context.Context, _ fakeExplainArgs, input io.Reader, out *report.Report) error {
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return err
+	}
+	out.Answer("bytes", len(data))
+
+	return nil
+}
+
+var fsys = fstest.MapFS{
+	"examples/examples.json": {Data: []byte(`[
+		{"input": "1.txt", "answers": {"sum": 6}},
+		{"input": "1.txt", "args": ["-f", "2"], "answers": {"sum": 6}}
+	]`)},
+	"examples/1.txt": {Data: []byte("1\n2\n3\n")},
+}
+
+// run parses the command line into A and runs the command on it, returning the answers written.
+func run[A any, P Args[A]](
+	t *testing.T, solve func(context.Context, A, io.Reader, *report.Report) error, commandLine ...string,
+) (map[string]any, error) {
+	t.Helper()
+	commandLine = append(commandLine, "--output", "json", "--log-level", "error")
+	args := P(new(A))
+	parser, err := arg.NewParser(arg.Config{IgnoreEnv: true}, args)
+	require.NoError(t, err)
+	require.NoError(t, parser.Parse(commandLine))
+
+	var stdout bytes.Buffer
+	err = Run(context.Background(), 3, fsys, solve, args, commandLine, &stdout)
+	if stdout.Len() == 0 {
+		return nil, err
+	}
+	var written struct {
+		Answers map[string]any `json:"answers"`
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &written))
+	return written.Answers, err
+}
+
+func writeInput(t *testing.T, path, text string) string {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(text), 0o600))
+	return path
+}
+
+func TestRunExample(t *testing.T) {
+	answers, err := run[fakeArgs](t, fakeSolve, "--example", "1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"sum": 6.0}, answers)
+
+	// The report is written even though the answer is wrong.
+	answers, err = run[fakeArgs](t, fakeSolve, "--example", "2")
+	require.ErrorIs(t, err, ErrExampleFailed)
+	assert.Equal(t, map[string]any{"sum": 12.0}, answers)
+
+	_, err = run[fakeArgs](t, fakeSolve, "--example", "3")
+	assert.EqualError(t, err, "example must be between 1 and 2; got 3")
+}
+
+func TestRunInputFile(t *testing.T) {
+	path := writeInput(t, filepath.Join(t.TempDir(), "input.txt"), "4\n5\n")
+	answers, err := run[fakeArgs](t, fakeSolve, path, "-f", "3")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"sum": 27.0}, answers)
+
+	path = writeInput(t, filepath.Join(t.TempDir(), "input.txt"), "4\nfive\n")
+	_, err = run[fakeArgs](t, fakeSolve, path)
+	var parseErr *parsing.Error
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, 2, parseErr.Line)
+
+	_, err = run[fakeArgs](t, fakeSolve, filepath.Join(t.TempDir(), "missing.txt"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRunCachedInput(t *testing.T) {
+	cacheDir := t.TempDir()
+	t.Setenv(input.CacheDirEnv, cacheDir)
+	_, err := run[fakeArgs](t, fakeSolve)
+	require.ErrorContains(t, err, "day 3 is not in the cache")
+
+	writeInput(t, input.CachePath(cacheDir, 3), "7\n")
+	answers, err := run[fakeArgs](t, fakeSolve)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"sum": 7.0}, answers)
+}
+
+func TestRunWithoutInput(t *testing.T) {
+	t.Setenv(input.CacheDirEnv, t.TempDir())
+	answers, err := run[fakeExplainArgs](t, fakeExplain, "--explain")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"bytes": 0.0}, answers)
+
+	// An input file given is still read.
+	path := writeInput(t, filepath.Join(t.TempDir(), "input.txt"), "abc")
+	answers, err = run[fakeExplainArgs](t, fakeExplain, path, "--explain")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"bytes": 3.0}, answers)
+
+	_, err = run[fakeExplainArgs](t, fakeExplain)
+	assert.ErrorContains(t, err, "day 3 is not in the cache")
+}
@@ -0,0 +1,42 @@
+// Package commandtest holds the tests every puzzle command runs: its examples, and a benchmark on the committed input.
+package commandtest
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"testing"
+
+	"common/examples"
+	"common/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Examples checks the answers to the examples of the puzzle statement.
+func Examples[A any](t *testing.T, exampleFS fs.FS, solve func(context.Context, A, io.Reader, *report.Report) error) {
+	t.Helper()
+	list, err := examples.Load(exampleFS)
+	require.NoError(t, err)
+	require.NotEmpty(t, list)
+	for n := 1; n <= len(list); n++ {
+		passed, err := examples.Run(context.Background(), exampleFS, n, nil, solve, report.New(report.Text))
+		require.NoError(t, err, "example %d", n)
+		assert.True(t, passed, "example %d", n)
+	}
+}
+
+// Benchmark runs the command end to end on the committed input, given with the options the puzzle needs for it, e.g. a
+// board size the examples do not use. Only errors are logged.
+func Benchmark(b *testing.B, main func(), commandLine ...string) {
+	b.Helper()
+	previous := os.Args
+	b.Cleanup(func() { os.Args = previous })
+	os.Args = append(append([]string{"puzzle"}, commandLine...), "--log-level", "error")
+
+	for range b.N {
+		main()
+	}
+}
@@ -0,0 +1,120 @@
+// Package examples runs a puzzle on the examples given in its statement, which every command embeds along with the
+// answers the statement gives for them, so that a solver can be checked without an input file.
+package examples
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"path"
+	"slices"
+
+	"common/report"
+
+	"github.com/alexflint/go-arg"
+)
+
+// Dir is the directory every command embeds its examples from, listed in Dir/examples.json.
+const Dir = "examples"
+
+type Flag struct {
+	Example int `arg:"--example" help:"solve example N of the puzzle statement instead of an input file, and check its answers"`
+}
+
+// Example is an input from the puzzle statement, the options it needs, e.g. a smaller board, and the answers the
+// statement gives for it. Answers not listed are not checked.
+type Example struct {
+	Input   string                     `json:"input"`
+	Args    []string                   `json:"args,omitempty"`
+	Answers map[string]json.RawMessage `json:"answers"`
+}
+
+// Load lists the examples in the order they are numbered, from 1.
+func Load(fsys fs.FS) ([]Example, error) {
+	data, err := fs.ReadFile(fsys, path.Join(Dir, "examples.json"))
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+
+	var examples []Example
+	err = json.Unmarshal(data, &examples)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path.Join(Dir, "examples.json"), err)
+	}
+
+	return examples, nil
+}
+
+// Run solves example n and logs whether its answers are the expected ones. The example's options are parsed into the
+// solver's Args before the command line, so that options given there win.
+func Run[A any](
+	ctx context.Context, fsys fs.FS, n int, commandLine []string,
+	solve func(context.Context, A, io.Reader, *report.Report) error, out *report.Report,
+) (bool, error) {
+	examples, err := Load(fsys)
+	if err != nil {
+		return false, err
+	}
+	if n < 1 || n > len(examples) {
+		return false, fmt.Errorf("example must be between 1 and %d; got %d", len(examples), n)
+	}
+	example := examples[n-1]
+
+	var args A
+	parser, err := arg.NewParser(arg.Config{IgnoreEnv: true}, &args)
+	if err != nil {
+		return false, err //nolint:wrapcheck // Toy code
+	}
+	err = parser.Parse(append(slices.Clone(example.Args), commandLine...))
+	if err != nil {
+		return false, fmt.Errorf("options of example %d: %w", n, err)
+	}
+
+	input, err := fsys.Open(path.Join(Dir, example.Input))
+	if err != nil {
+		return false, err //nolint:wrapcheck // Toy code
+	}
+	defer input.Close()
+
+	err = solve(ctx, args, input, out)
+	if err != nil {
+		return false, err
+	}
+
+	return Check(n, example, out.Answers()), nil
+}
+
+// Check compares the answers with the expected ones as JSON, so that numbers of any type compare by their value, and
+// logs every difference.
+func Check(n int, example Example, answers map[string]any) bool {
+	keys := make([]string, 0, len(example.Answers))
+	for key := range example.Answers {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	passed := true
+	for _, key := range keys {
+		var want bytes.Buffer
+		err := json.Compact(&want, example.Answers[key])
+		if err != nil {
+			slog.Error("expected answer is not valid JSON", "example", n, "answer", key, "error", err)
+			passed = false
+			continue
+		}
+		got, err := json.Marshal(answers[key])
+		if err != nil || !bytes.Equal(got, want.Bytes()) {
+			slog.Error("example failed", "example", n, "answer", key, "got", string(got), "want", want.String())
+			passed = false
+		}
+	}
+	if passed {
+		slog.Info("example passed", "example", n, "answers", len(keys))
+	}
+
+	return passed
+}
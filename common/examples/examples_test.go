@@ -0,0 +1,67 @@
+package examples
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"common/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeArgs struct {
+	InputFile string `arg:"positional"`
+	Factor    int    `arg:"-f" default:"1"`
+
+	Flag
+}
+
+// fakeSolve counts the lines of the input, times the factor.
+func fakeSolve(_ context.Context, args fakeArgs, input io.Reader, out *report.Report) error {
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return err
+	}
+	out.Answer("lines", strings.Count(string(data), "\n")*args.Factor)
+	out.Answer("first", strings.SplitN(string(data), "\n", 2)[0])
+
+	return nil
+}
+
+var fsys = fstest.MapFS{
+	"examples/examples.json": {Data: []byte(`[
+		{"input": "1.txt", "answers": {"lines": 3, "first": "a"}},
+		{"input": "1.txt", "args": ["-f", "10"], "answers": {"lines": 30}},
+		{"input": "2.txt", "answers": {"lines": 2}}
+	]`)},
+	"examples/1.txt": {Data: []byte("a\nb\nc\n")},
+	"examples/2.txt": {Data: []byte("x\n")},
+}
+
+func TestRun(t *testing.T) {
+	passed, err := Run(context.Background(), fsys, 1, []string{"--example", "1"}, fakeSolve, report.New(report.Text))
+	require.NoError(t, err)
+	assert.True(t, passed)
+
+	out := report.New(report.Text)
+	passed, err = Run(context.Background(), fsys, 2, []string{"--example", "2"}, fakeSolve, out)
+	require.NoError(t, err)
+	assert.True(t, passed)
+	assert.Equal(t, map[string]any{"lines": 30, "first": "a"}, out.Answers())
+
+	// Options on the command line win over the example's.
+	passed, err = Run(context.Background(), fsys, 2, []string{"--example", "2", "-f", "2"}, fakeSolve, report.New(report.Text))
+	require.NoError(t, err)
+	assert.False(t, passed)
+
+	passed, err = Run(context.Background(), fsys, 3, nil, fakeSolve, report.New(report.Text))
+	require.NoError(t, err)
+	assert.False(t, passed)
+
+	_, err = Run(context.Background(), fsys, 4, nil, fakeSolve, report.New(report.Text))
+	require.EqualError(t, err, "example must be between 1 and 3; got 4")
+}
@@ -0,0 +1,221 @@
+// Package input keeps the puzzle inputs in a local cache, downloading them from the puzzle website when asked to, so
+// that the commands can run without being given an input file.
+package input
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	Year             = 2024
+	DefaultBaseURL   = "https://adventofcode.com"
+	DefaultInterval  = 15 * time.Second
+	DefaultUserAgent = "aoc-2024 tools"
+	// CacheDirEnv overrides the cache directory for both the fetcher and the commands.
+	CacheDirEnv = "AOC_CACHE_DIR"
+)
+
+// ErrCached is returned instead of downloading an input that is already in the cache.
+var ErrCached = errors.New("input already cached")
+
+// CacheDir is where inputs are kept: the directory named by AOC_CACHE_DIR, or `aoc` in the user's cache directory.
+func CacheDir() (string, error) {
+	dir := os.Getenv(CacheDirEnv)
+	if dir != "" {
+		return dir, nil
+	}
+
+	userDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot find a cache directory, set %s: %w", CacheDirEnv, err)
+	}
+
+	return filepath.Join(userDir, "aoc"), nil
+}
+
+// CachePath is where the input of a day is kept in the cache, e.g. `2024/day-07.txt`.
+func CachePath(cacheDir string, day int) string {
+	return filepath.Join(cacheDir, fmt.Sprint(Year), fmt.Sprintf("day-%02d.txt", day))
+}
+
+// Resolve returns the input file to read: the given path when there is one, and the cached input of the day otherwise.
+func Resolve(path string, day int) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+
+	cacheDir, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+	cached := CachePath(cacheDir, day)
+	_, err = os.Stat(cached)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("no input file given and day %d is not in the cache at %s; fetch it first", day, cached)
+	}
+	if err != nil {
+		return "", err //nolint:wrapcheck // Toy code
+	}
+
+	return cached, nil
+}
+
+// Fetcher downloads inputs into the cache, leaving at least Interval between requests. The time of the last request is
+// kept in the cache too, so that the interval holds across runs.
+type Fetcher struct {
+	BaseURL   string
+	Session   string
+	CacheDir  string
+	Interval  time.Duration
+	UserAgent string
+	Client    *http.Client
+
+	now   func() time.Time
+	sleep func(context.Context, time.Duration) error
+}
+
+func NewFetcher(session, cacheDir string) *Fetcher {
+	return &Fetcher{
+		BaseURL:   DefaultBaseURL,
+		Session:   session,
+		CacheDir:  cacheDir,
+		Interval:  DefaultInterval,
+		UserAgent: DefaultUserAgent,
+		Client:    http.DefaultClient,
+		now:       time.Now,
+		sleep:     sleep,
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck // Toy code
+	}
+}
+
+// Fetch downloads the input of a day and returns the path it is cached at. An input already in the cache is never
+// downloaded again: its path is returned along with ErrCached.
+func (f *Fetcher) Fetch(ctx context.Context, day int) (string, error) {
+	if day < 1 || day > 25 {
+		return "", fmt.Errorf("day must be between 1 and 25; got %d", day)
+	}
+	path := CachePath(f.CacheDir, day)
+	_, err := os.Stat(path)
+	if err == nil {
+		return path, ErrCached
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", err //nolint:wrapcheck // Toy code
+	}
+	if f.Session == "" {
+		return "", errors.New("a session token is required to download inputs")
+	}
+
+	err = f.waitTurn(ctx)
+	if err != nil {
+		return "", err
+	}
+	data, err := f.download(ctx, day)
+	if err != nil {
+		return "", err
+	}
+
+	return path, writeFile(path, data)
+}
+
+func (f *Fetcher) stampPath() string {
+	return filepath.Join(f.CacheDir, "last-request")
+}
+
+// waitTurn sleeps until the interval since the last request is over, then records this request as the last one.
+func (f *Fetcher) waitTurn(ctx context.Context) error {
+	info, err := os.Stat(f.stampPath())
+	if err == nil {
+		wait := f.Interval - f.now().Sub(info.ModTime())
+		if wait > 0 {
+			err = f.sleep(ctx, wait)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = writeFile(f.stampPath(), nil)
+	if err != nil {
+		return err
+	}
+	now := f.now()
+
+	return os.Chtimes(f.stampPath(), now, now) //nolint:wrapcheck // Toy code
+}
+
+func (f *Fetcher) download(ctx context.Context, day int) ([]byte, error) {
+	url := fmt.Sprintf("%s/%d/day/%d/input", strings.TrimSuffix(f.BaseURL, "/"), Year, day)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+	request.AddCookie(&http.Cookie{Name: "session", Value: f.Session})
+	request.Header.Set("User-Agent", f.UserAgent)
+
+	response, err := f.Client.Do(request)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading day %d: %s: %s", day, response.Status, firstLine(data))
+	}
+
+	return data, nil
+}
+
+func firstLine(data []byte) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(string(data)), "\n")
+
+	return line
+}
+
+// writeFile replaces the file through a temporary one, so that an interrupted download never leaves a partial input in
+// the cache. Inputs are personal, so only the user may read them.
+func writeFile(path string, data []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+	_, err = temp.Write(data)
+	closeErr := temp.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(temp.Name())
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	return os.Rename(temp.Name(), path) //nolint:wrapcheck // Toy code
+}
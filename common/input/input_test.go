@@ -0,0 +1,118 @@
+package input
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSite serves inputs the way the puzzle website does, refusing requests without the right session cookie and days
+// that are not unlocked yet.
+func fakeSite(t *testing.T, requests *[]string) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /2024/day/{day}/input", func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.Path)
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "secret" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		if r.PathValue("day") == "25" {
+			http.Error(w, "Please don't repeatedly request this endpoint before it unlocks!", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("input of day " + r.PathValue("day") + "\n"))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newTestFetcher(t *testing.T, baseURL string, slept *[]time.Duration) *Fetcher {
+	t.Helper()
+	fetcher := NewFetcher("secret", t.TempDir())
+	fetcher.BaseURL = baseURL
+	clock := time.Date(2024, 12, 1, 5, 0, 0, 0, time.UTC)
+	fetcher.now = func() time.Time { return clock }
+	fetcher.sleep = func(_ context.Context, d time.Duration) error {
+		*slept = append(*slept, d)
+		clock = clock.Add(d)
+		return nil
+	}
+
+	return fetcher
+}
+
+func TestFetch(t *testing.T) {
+	var requests []string
+	var slept []time.Duration
+	site := fakeSite(t, &requests)
+	fetcher := newTestFetcher(t, site.URL, &slept)
+
+	path, err := fetcher.Fetch(context.Background(), 3)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(fetcher.CacheDir, "2024", "day-03.txt"), path)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "input of day 3\n", string(data))
+
+	path, err = fetcher.Fetch(context.Background(), 3)
+	require.ErrorIs(t, err, ErrCached)
+	assert.Equal(t, filepath.Join(fetcher.CacheDir, "2024", "day-03.txt"), path)
+	assert.Equal(t, []string{"/2024/day/3/input"}, requests)
+
+	_, err = fetcher.Fetch(context.Background(), 4)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{DefaultInterval}, slept)
+	assert.Equal(t, []string{"/2024/day/3/input", "/2024/day/4/input"}, requests)
+}
+
+func TestFetchErrors(t *testing.T) {
+	var requests []string
+	var slept []time.Duration
+	site := fakeSite(t, &requests)
+	fetcher := newTestFetcher(t, site.URL, &slept)
+
+	_, err := fetcher.Fetch(context.Background(), 25)
+	require.EqualError(t, err,
+		"downloading day 25: 404 Not Found: Please don't repeatedly request this endpoint before it unlocks!")
+	assert.NoFileExists(t, CachePath(fetcher.CacheDir, 25))
+
+	fetcher.Session = "stale"
+	_, err = fetcher.Fetch(context.Background(), 1)
+	require.ErrorContains(t, err, "400 Bad Request: Puzzle inputs differ by user.")
+
+	fetcher.Session = ""
+	_, err = fetcher.Fetch(context.Background(), 1)
+	require.EqualError(t, err, "a session token is required to download inputs")
+
+	_, err = fetcher.Fetch(context.Background(), 26)
+	require.EqualError(t, err, "day must be between 1 and 25; got 26")
+	assert.Len(t, requests, 2)
+}
+
+func TestResolve(t *testing.T) {
+	cacheDir := t.TempDir()
+	t.Setenv(CacheDirEnv, cacheDir)
+
+	path, err := Resolve("input.txt", 7)
+	require.NoError(t, err)
+	assert.Equal(t, "input.txt", path)
+
+	_, err = Resolve("", 7)
+	require.ErrorContains(t, err, "no input file given and day 7 is not in the cache")
+
+	require.NoError(t, writeFile(CachePath(cacheDir, 7), []byte("1\n")))
+	path, err = Resolve("", 7)
+	require.NoError(t, err)
+	assert.Equal(t, CachePath(cacheDir, 7), path)
+}
@@ -0,0 +1,43 @@
+// Package logging sets up the log/slog logger shared by the puzzle commands, so that their debug output can be switched
+// on from the command line instead of by uncommenting code.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+)
+
+// Flags is embedded in each command's go-arg Args.
+type Flags struct {
+	Verbose  bool       `arg:"-v,--verbose" help:"log debug output too; same as --log-level debug"`
+	LogLevel slog.Level `arg:"--log-level"  default:"info" help:"least severe level to log: debug, info, warn or error"`
+}
+
+// Level is the least severe level to log; --verbose lowers it to debug.
+func (f Flags) Level() slog.Level {
+	if f.Verbose {
+		return min(f.LogLevel, slog.LevelDebug)
+	}
+
+	return f.LogLevel
+}
+
+func New(w io.Writer, flags Flags) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: flags.Level()}))
+}
+
+// Setup makes a logger writing to stderr the slog default. This routes the standard log package through it too, so
+// log.Fatal and log.Panic keep working and share its format; as nothing else uses that package anymore, its records are
+// logged as errors.
+func Setup(flags Flags) {
+	slog.SetDefault(New(os.Stderr, flags))
+	slog.SetLogLoggerLevel(slog.LevelError)
+}
+
+// DebugEnabled tells whether debug records are logged, to guard debug output that is costly to produce or that is not a
+// log record, such as a rendered board.
+func DebugEnabled() bool {
+	return slog.Default().Enabled(context.Background(), slog.LevelDebug)
+}
@@ -0,0 +1,41 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevel(t *testing.T) {
+	var flags Flags
+	require.NoError(t, flags.LogLevel.UnmarshalText([]byte("warn")))
+	assert.Equal(t, slog.LevelWarn, flags.Level())
+
+	flags.Verbose = true
+	assert.Equal(t, slog.LevelDebug, flags.Level())
+}
+
+func TestNew(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := New(&buffer, Flags{LogLevel: slog.LevelInfo})
+	logger.Debug("step", "n", 1)
+	logger.Info("total", "value", 42)
+
+	assert.NotContains(t, buffer.String(), "step")
+	assert.Contains(t, buffer.String(), "level=INFO msg=total value=42")
+}
+
+func TestDebugEnabled(t *testing.T) {
+	previous := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	var buffer bytes.Buffer
+	slog.SetDefault(New(&buffer, Flags{Verbose: true}))
+	assert.True(t, DebugEnabled())
+
+	slog.SetDefault(New(&buffer, Flags{LogLevel: slog.LevelInfo}))
+	assert.False(t, DebugEnabled())
+}
@@ -0,0 +1,63 @@
+// Package parsing holds the error returned by every day's input readers, so that the commands and the server can point
+// at the offending part of a hand-edited input file the same way.
+package parsing
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error locates a problem in the input file. Line and Col are 1-based, and Text is the offending part of the line.
+type Error struct {
+	File string
+	Line int
+	Col  int
+	Text string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	file := e.File
+	if file == "" {
+		file = "<input>"
+	}
+	if e.Text == "" {
+		return fmt.Sprintf("%s:%d:%d: %v", file, e.Line, e.Col, e.Err)
+	}
+	return fmt.Sprintf("%s:%d:%d: %v: %q", file, e.Line, e.Col, e.Err, e.Text)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Errorf returns an Error at the given line and column, formatting the message like fmt.Errorf.
+func Errorf(line, col int, text string, format string, args ...any) *Error {
+	return &Error{Line: line, Col: col, Text: text, Err: fmt.Errorf(format, args...)}
+}
+
+// Diagnostic formats err compiler style, as file:line:col: message, when it is located in the named input file, or in
+// the file InFile tied it to. err is left as it is, so that it can still be reported against another file.
+func Diagnostic(file string, err error) string {
+	var parseErr *Error
+	if errors.As(err, &parseErr) {
+		located := *parseErr
+		if located.File == "" {
+			located.File = file
+		}
+		return located.Error()
+	}
+	return fmt.Sprintf("%s: %v", file, err)
+}
+
+// InFile ties err to the named file other than the input, e.g. a rules file: a parse error is located in the file, and
+// any other error is prefixed with its name. The error returned still wraps err.
+func InFile(file string, err error) error {
+	var parseErr *Error
+	if errors.As(err, &parseErr) {
+		located := *parseErr
+		located.File = file
+		return &located
+	}
+	return fmt.Errorf("%s: %w", file, err)
+}
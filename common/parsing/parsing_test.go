@@ -0,0 +1,46 @@
+package parsing
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError(t *testing.T) {
+	assert.EqualError(t, Errorf(3, 7, "x1", "expected a number"), `<input>:3:7: expected a number: "x1"`)
+	assert.EqualError(t, Errorf(1, 4, "", "expected %d fields", 2), "<input>:1:4: expected 2 fields")
+
+	err := &Error{File: "input.txt", Line: 2, Col: 1, Err: io.ErrUnexpectedEOF}
+	assert.EqualError(t, err, "input.txt:2:1: unexpected EOF")
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestDiagnostic(t *testing.T) {
+	parseErr := Errorf(3, 7, "x1", "expected a number")
+	wrapped := fmt.Errorf("reading rules: %w", parseErr)
+	assert.Equal(t, `rules.txt:3:7: expected a number: "x1"`, Diagnostic("rules.txt", wrapped))
+	assert.Equal(t, `input.txt:3:7: expected a number: "x1"`, Diagnostic("input.txt", parseErr))
+	// The error itself is not tied to any of the files.
+	assert.Empty(t, parseErr.File)
+
+	assert.Equal(t, "input.txt: no rules found", Diagnostic("input.txt", errors.New("no rules found")))
+}
+
+func TestInFile(t *testing.T) {
+	parseErr := Errorf(3, 7, "x1", "expected a number")
+	err := InFile("rules.txt", fmt.Errorf("reading rules: %w", parseErr))
+	assert.EqualError(t, err, `rules.txt:3:7: expected a number: "x1"`)
+	var located *Error
+	assert.ErrorAs(t, err, &located)
+	assert.Equal(t, 3, located.Line)
+	assert.Empty(t, parseErr.File)
+	// A located error is reported against its own file rather than the input file.
+	assert.Equal(t, `rules.txt:3:7: expected a number: "x1"`, Diagnostic("input.txt", err))
+
+	err = InFile("rules.txt", io.ErrUnexpectedEOF)
+	assert.EqualError(t, err, "rules.txt: unexpected EOF")
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
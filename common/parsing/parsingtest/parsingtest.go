@@ -0,0 +1,32 @@
+// Package parsingtest helps fuzz the input readers of the puzzles.
+package parsingtest
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+
+	"common/parsing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// CheckError asserts that a failed read of input reports a parsing.Error located within the input, or just past its
+// end. Lines too long for the scanner are the only other failure allowed.
+func CheckError(t *testing.T, input string, err error) {
+	t.Helper()
+	if errors.Is(err, bufio.ErrTooLong) {
+		return
+	}
+	var parseErr *parsing.Error
+	require.ErrorAs(t, err, &parseErr)
+	lines := strings.Split(input, "\n")
+	require.GreaterOrEqual(t, parseErr.Line, 1)
+	require.LessOrEqual(t, parseErr.Line, len(lines)+1)
+	assert.GreaterOrEqual(t, parseErr.Col, 1)
+	if parseErr.Line <= len(lines) {
+		assert.LessOrEqual(t, parseErr.Col, len(lines[parseErr.Line-1])+1)
+	}
+}
@@ -0,0 +1,18 @@
+package parsingtest
+
+import (
+	"bufio"
+	"fmt"
+	"testing"
+
+	"common/parsing"
+)
+
+func TestCheckError(t *testing.T) {
+	input := "12 34\n5x\n"
+	CheckError(t, input, parsing.Errorf(2, 2, "x", "expected a number"))
+	CheckError(t, input, fmt.Errorf("reading rules: %w", parsing.Errorf(2, 3, "", "expected 2 numbers, got 1")))
+	// Just past the last line.
+	CheckError(t, input, parsing.Errorf(3, 1, "", "missing moves"))
+	CheckError(t, input, bufio.ErrTooLong)
+}
@@ -0,0 +1,137 @@
+// Package profiling writes CPU and heap profiles and execution traces of a command, and sums up its allocations and wall
+// time when it is done.
+package profiling
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"time"
+
+	"common/logging"
+)
+
+// Files names the files to write the profiles to; each one is only written when named.
+type Files struct {
+	CPUProfile string `arg:"--cpuprofile" help:"write a CPU profile to this file"`
+	MemProfile string `arg:"--memprofile" help:"write a heap profile to this file when done"`
+	Trace      string `arg:"--trace"      help:"write an execution trace to this file"`
+}
+
+func (f Files) any() bool {
+	return f.CPUProfile != "" || f.MemProfile != "" || f.Trace != ""
+}
+
+type session struct {
+	files  Files
+	start  time.Time
+	before runtime.MemStats
+	cpu    *os.File
+	trace  *os.File
+	level  slog.Level
+}
+
+// Start starts the profiles asked for and returns the function that stops them. Stopping writes the heap profile and
+// logs a summary of the allocations and wall time, at info level when profiling and at debug level otherwise. Commands
+// stop on errors too, so that the profiles of a failed run are complete.
+func Start(files Files) (func(), error) {
+	s := &session{files: files, start: time.Now(), level: slog.LevelDebug}
+	if files.any() {
+		s.level = slog.LevelInfo
+	}
+	if files.any() || logging.DebugEnabled() {
+		// Reading the statistics stops the world, so it is skipped unless the summary is logged.
+		runtime.ReadMemStats(&s.before)
+	}
+
+	if files.CPUProfile != "" {
+		cpu, err := os.Create(files.CPUProfile)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Toy code
+		}
+		err = pprof.StartCPUProfile(cpu)
+		if err != nil {
+			_ = cpu.Close()
+			return nil, err //nolint:wrapcheck // Toy code
+		}
+		s.cpu = cpu
+	}
+
+	if files.Trace != "" {
+		traceFile, err := os.Create(files.Trace)
+		if err != nil {
+			s.stopCPU()
+			return nil, err //nolint:wrapcheck // Toy code
+		}
+		err = trace.Start(traceFile)
+		if err != nil {
+			s.stopCPU()
+			_ = traceFile.Close()
+			return nil, err //nolint:wrapcheck // Toy code
+		}
+		s.trace = traceFile
+	}
+
+	return s.stop, nil
+}
+
+func (s *session) stopCPU() {
+	if s.cpu == nil {
+		return
+	}
+	pprof.StopCPUProfile()
+	err := s.cpu.Close()
+	if err != nil {
+		slog.Error("could not write the CPU profile", "file", s.files.CPUProfile, "error", err)
+	}
+}
+
+func (s *session) stop() {
+	wall := time.Since(s.start)
+	s.stopCPU()
+
+	if s.trace != nil {
+		trace.Stop()
+		err := s.trace.Close()
+		if err != nil {
+			slog.Error("could not write the trace", "file", s.files.Trace, "error", err)
+		}
+	}
+
+	if s.files.MemProfile != "" {
+		err := writeHeapProfile(s.files.MemProfile)
+		if err != nil {
+			slog.Error("could not write the heap profile", "file", s.files.MemProfile, "error", err)
+		}
+	}
+
+	if !s.files.any() && !logging.DebugEnabled() {
+		return
+	}
+	var after runtime.MemStats
+	runtime.ReadMemStats(&after)
+	slog.Log(context.Background(), s.level, "run summary",
+		"wall", wall,
+		"allocBytes", after.TotalAlloc-s.before.TotalAlloc,
+		"allocs", after.Mallocs-s.before.Mallocs,
+		"gcCycles", after.NumGC-s.before.NumGC,
+		"heapSysBytes", after.HeapSys,
+	)
+}
+
+// writeHeapProfile collects garbage first, so that the profile shows the live heap as of the end of the run.
+func writeHeapProfile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	runtime.GC()
+	err = pprof.WriteHeapProfile(file)
+
+	return errors.Join(err, file.Close())
+}
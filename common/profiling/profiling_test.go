@@ -0,0 +1,75 @@
+package profiling
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"common/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureLogs makes the default logger write to the returned buffer for the rest of the test.
+func captureLogs(t *testing.T, flags logging.Flags) *bytes.Buffer {
+	t.Helper()
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logging.New(&logs, flags))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	return &logs
+}
+
+func TestStart(t *testing.T) {
+	logs := captureLogs(t, logging.Flags{})
+	dir := t.TempDir()
+	files := Files{
+		CPUProfile: filepath.Join(dir, "cpu.pprof"),
+		MemProfile: filepath.Join(dir, "mem.pprof"),
+		Trace:      filepath.Join(dir, "trace.out"),
+	}
+
+	stop, err := Start(files)
+	require.NoError(t, err)
+	words := strings.Fields(strings.Repeat("allocate some memory ", 1000))
+	assert.Len(t, words, 3000)
+	stop()
+
+	for _, path := range []string{files.CPUProfile, files.MemProfile, files.Trace} {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Positive(t, info.Size(), path)
+	}
+	assert.Contains(t, logs.String(), "level=INFO msg=\"run summary\" wall=")
+	assert.Contains(t, logs.String(), "allocBytes=")
+}
+
+func TestStartWithoutFiles(t *testing.T) {
+	logs := captureLogs(t, logging.Flags{})
+	stop, err := Start(Files{})
+	require.NoError(t, err)
+	stop()
+	assert.Empty(t, logs.String())
+
+	logs = captureLogs(t, logging.Flags{Verbose: true})
+	stop, err = Start(Files{})
+	require.NoError(t, err)
+	stop()
+	assert.Contains(t, logs.String(), "level=DEBUG msg=\"run summary\"")
+}
+
+func TestStartFailing(t *testing.T) {
+	dir := t.TempDir()
+	_, err := Start(Files{CPUProfile: filepath.Join(dir, "cpu.pprof"), Trace: filepath.Join(dir, "missing", "trace.out")})
+	require.Error(t, err)
+
+	// The CPU profile started before the trace failed must have been stopped, or starting another one would fail.
+	stop, err := Start(Files{CPUProfile: filepath.Join(t.TempDir(), "cpu.pprof")})
+	require.NoError(t, err)
+	stop()
+}
@@ -0,0 +1,122 @@
+// Package report collects a puzzle's answers, along with statistics about the parsed input, so that they can be written
+// as a single JSON object for tools that compare answers automatically.
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"time"
+)
+
+type Format string
+
+const (
+	Text Format = "text"
+	JSON Format = "json"
+)
+
+// UnmarshalText lets the format be used as a go-arg flag.
+func (f *Format) UnmarshalText(text []byte) error {
+	switch format := Format(text); format {
+	case Text, JSON:
+		*f = format
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q, expected %q or %q", text, Text, JSON)
+	}
+}
+
+// Report records answers and parse statistics under camelCase keys. The clock starts when the report is created.
+type Report struct {
+	Format  Format
+	start   time.Time
+	answers map[string]any
+	parse   map[string]int
+	input   *countingReader
+}
+
+func New(format Format) *Report {
+	if format == "" {
+		format = Text
+	}
+
+	return &Report{Format: format, start: time.Now(), answers: make(map[string]any), parse: make(map[string]int)}
+}
+
+func (r *Report) IsJSON() bool {
+	return r.Format == JSON
+}
+
+func (r *Report) Answer(key string, value any) {
+	r.answers[key] = value
+}
+
+// Answers returns a copy of the answers recorded so far.
+func (r *Report) Answers() map[string]any {
+	return maps.Clone(r.answers)
+}
+
+func (r *Report) Parsed(key string, count int) {
+	r.parse[key] = count
+}
+
+// CountInput wraps the input so that the bytes and lines read from it are added to the parse statistics.
+func (r *Report) CountInput(reader io.Reader) io.Reader {
+	r.input = &countingReader{reader: reader}
+
+	return r.input
+}
+
+type countingReader struct {
+	reader   io.Reader
+	bytes    int
+	newlines int
+	lastByte byte
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	if n > 0 {
+		c.bytes += n
+		c.newlines += bytes.Count(p[:n], []byte{'\n'})
+		c.lastByte = p[n-1]
+	}
+
+	return n, err //nolint:wrapcheck // Passed through unchanged, as io.EOF must be
+}
+
+// lines counts a last line that lacks its newline too.
+func (c *countingReader) lines() int {
+	if c.bytes > 0 && c.lastByte != '\n' {
+		return c.newlines + 1
+	}
+
+	return c.newlines
+}
+
+type object struct {
+	Answers        map[string]any `json:"answers"`
+	Parse          map[string]int `json:"parse"`
+	ElapsedSeconds float64        `json:"elapsedSeconds"`
+}
+
+// Write writes the report as one line of JSON when the format asks for it, and nothing otherwise, as the answers have
+// then already been logged.
+func (r *Report) Write(w io.Writer) error {
+	if !r.IsJSON() {
+		return nil
+	}
+	if r.input != nil {
+		r.parse["bytes"] = r.input.bytes
+		r.parse["lines"] = r.input.lines()
+	}
+
+	return json.NewEncoder(w).Encode(object{ //nolint:wrapcheck // Toy code
+		Answers:        r.answers,
+		Parse:          r.parse,
+		ElapsedSeconds: time.Since(r.start).Seconds(),
+	})
+}
@@ -0,0 +1,44 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatUnmarshalText(t *testing.T) {
+	var format Format
+	require.NoError(t, format.UnmarshalText([]byte("json")))
+	assert.Equal(t, JSON, format)
+	assert.Error(t, format.UnmarshalText([]byte("xml")))
+}
+
+func TestWrite(t *testing.T) {
+	out := New(JSON)
+	_, err := io.ReadAll(out.CountInput(strings.NewReader("3   4\n4   3\n2   5")))
+	require.NoError(t, err)
+	out.Parsed("pairs", 3)
+	out.Answer("distance", 11)
+
+	var buffer bytes.Buffer
+	require.NoError(t, out.Write(&buffer))
+	var written map[string]any
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &written))
+	assert.Equal(t, map[string]any{"distance": 11.0}, written["answers"])
+	assert.Equal(t, map[string]any{"pairs": 3.0, "bytes": 17.0, "lines": 3.0}, written["parse"])
+	assert.Contains(t, written, "elapsedSeconds")
+}
+
+func TestWriteText(t *testing.T) {
+	out := New(Text)
+	out.Answer("distance", 11)
+
+	var buffer bytes.Buffer
+	require.NoError(t, out.Write(&buffer))
+	assert.Empty(t, buffer.String())
+}
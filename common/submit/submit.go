@@ -0,0 +1,336 @@
+// Package submit posts answers to the puzzle website, keeping every answer and its verdict in a history file so that
+// answers known to be wrong, or known to be out of bounds, are never submitted twice.
+package submit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"common/input"
+)
+
+type Verdict string
+
+const (
+	Correct       Verdict = "correct"
+	TooHigh       Verdict = "tooHigh"
+	TooLow        Verdict = "tooLow"
+	Wrong         Verdict = "wrong"
+	Throttled     Verdict = "throttled"
+	AlreadySolved Verdict = "alreadySolved"
+)
+
+// IsWrong tells whether the answer was checked and rejected.
+func (v Verdict) IsWrong() bool {
+	return v == TooHigh || v == TooLow || v == Wrong
+}
+
+// Submission is one answer posted to the website, along with what it said.
+type Submission struct {
+	Part    int       `json:"part"`
+	Answer  string    `json:"answer"`
+	Verdict Verdict   `json:"verdict"`
+	Time    time.Time `json:"time"`
+}
+
+// History records the submissions per day, and until when the website asked to wait before submitting again.
+type History struct {
+	WaitUntil time.Time            `json:"waitUntil"`
+	Days      map[int][]Submission `json:"days"`
+}
+
+// ReadHistory reads the history file, which is taken to be empty when it does not exist yet.
+func ReadHistory(path string) (History, error) {
+	history := History{Days: make(map[int][]Submission)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return history, nil
+	}
+	if err != nil {
+		return History{}, err //nolint:wrapcheck // Toy code
+	}
+
+	err = json.Unmarshal(data, &history)
+	if err != nil {
+		return History{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if history.Days == nil {
+		history.Days = make(map[int][]Submission)
+	}
+
+	return history, nil
+}
+
+// WriteHistory replaces the history file, going through a temporary file so that an interrupted write leaves the old
+// history in place.
+func WriteHistory(path string, history History) error {
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+	tempPath := path + ".tmp"
+	err = os.WriteFile(tempPath, append(data, '\n'), 0o600) //nolint:mnd // Private, like the session it was made with
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	return os.Rename(tempPath, path) //nolint:wrapcheck // Toy code
+}
+
+// RefusalError explains why an answer was not submitted.
+type RefusalError struct {
+	Reason string
+}
+
+func (e *RefusalError) Error() string {
+	return "not submitting: " + e.Reason
+}
+
+// Check refuses answers the history already rules out: any answer to a part already solved, an answer submitted
+// before, and a number beyond an answer found too high or too low.
+func (h History) Check(day, part int, answer string, now time.Time) error {
+	if now.Before(h.WaitUntil) {
+		return &RefusalError{Reason: fmt.Sprintf("the website asked to wait %s more", h.WaitUntil.Sub(now).Round(time.Second))}
+	}
+
+	value, isNumber := new(big.Int).SetString(answer, 10)
+	for _, submission := range h.Days[day] {
+		if submission.Part != part {
+			continue
+		}
+		if submission.Verdict == Correct {
+			return &RefusalError{Reason: fmt.Sprintf("day %d part %d was already solved with %s", day, part, submission.Answer)}
+		}
+		if submission.Answer == answer && submission.Verdict.IsWrong() {
+			return &RefusalError{Reason: fmt.Sprintf("%s was already found %s", answer, describe(submission.Verdict))}
+		}
+
+		bound, boundIsNumber := new(big.Int).SetString(submission.Answer, 10)
+		if !isNumber || !boundIsNumber {
+			continue
+		}
+		if submission.Verdict == TooHigh && value.Cmp(bound) >= 0 {
+			return &RefusalError{Reason: fmt.Sprintf("%s is not below %s, which was too high", answer, submission.Answer)}
+		}
+		if submission.Verdict == TooLow && value.Cmp(bound) <= 0 {
+			return &RefusalError{Reason: fmt.Sprintf("%s is not above %s, which was too low", answer, submission.Answer)}
+		}
+	}
+
+	return nil
+}
+
+func describe(verdict Verdict) string {
+	switch verdict {
+	case TooHigh:
+		return "too high"
+	case TooLow:
+		return "too low"
+	default:
+		return "wrong"
+	}
+}
+
+var (
+	articlePattern = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagPattern     = regexp.MustCompile(`<[^>]*>`)
+	leftPattern    = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+	waitPattern    = regexp.MustCompile(`(?i)please wait (one|\d+) minutes? before trying again`)
+)
+
+// Response is what the website said about an answer: its verdict, the message itself, and how long to wait before
+// submitting again.
+type Response struct {
+	Verdict Verdict
+	Message string
+	Wait    time.Duration
+}
+
+// ParseResponse reads the verdict from the page the website answers a submission with.
+func ParseResponse(page string) (Response, error) {
+	match := articlePattern.FindStringSubmatch(page)
+	if match == nil {
+		return Response{}, errors.New("no message found in the response")
+	}
+	message := strings.Join(strings.Fields(html.UnescapeString(tagPattern.ReplaceAllString(match[1], ""))), " ")
+	response := Response{Message: message, Wait: parseWait(message)}
+
+	switch {
+	case strings.Contains(message, "That's the right answer"):
+		response.Verdict = Correct
+	case strings.Contains(message, "your answer is too high"):
+		response.Verdict = TooHigh
+	case strings.Contains(message, "your answer is too low"):
+		response.Verdict = TooLow
+	case strings.Contains(message, "That's not the right answer"):
+		response.Verdict = Wrong
+	case strings.Contains(message, "You gave an answer too recently"):
+		response.Verdict = Throttled
+	case strings.Contains(message, "Did you already complete it"):
+		response.Verdict = AlreadySolved
+	default:
+		return Response{}, fmt.Errorf("unrecognised response: %s", message)
+	}
+
+	return response, nil
+}
+
+func parseWait(message string) time.Duration {
+	if match := leftPattern.FindStringSubmatch(message); match != nil {
+		minutes, _ := strconv.Atoi(match[1])
+		seconds, _ := strconv.Atoi(match[2])
+		return time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	}
+	if match := waitPattern.FindStringSubmatch(message); match != nil {
+		if match[1] == "one" {
+			return time.Minute
+		}
+		minutes, _ := strconv.Atoi(match[1])
+		return time.Duration(minutes) * time.Minute
+	}
+
+	return 0
+}
+
+// Client submits answers, checking them against the history first and recording what the website said after.
+type Client struct {
+	BaseURL     string
+	Session     string
+	HistoryFile string
+	UserAgent   string
+	Client      *http.Client
+
+	now func() time.Time
+}
+
+func NewClient(session, historyFile string) *Client {
+	return &Client{
+		BaseURL:     input.DefaultBaseURL,
+		Session:     session,
+		HistoryFile: historyFile,
+		UserAgent:   input.DefaultUserAgent,
+		Client:      http.DefaultClient,
+		now:         time.Now,
+	}
+}
+
+// Submit posts the answer to a part of a day, unless the history refuses it with a RefusalError.
+func (c *Client) Submit(ctx context.Context, day, part int, answer string) (Response, error) {
+	if day < 1 || day > 25 {
+		return Response{}, fmt.Errorf("day must be between 1 and 25; got %d", day)
+	}
+	if part != 1 && part != 2 {
+		return Response{}, fmt.Errorf("part must be 1 or 2; got %d", part)
+	}
+	if answer == "" {
+		return Response{}, errors.New("the answer is empty")
+	}
+	if c.Session == "" {
+		return Response{}, errors.New("a session token is required to submit answers")
+	}
+
+	history, err := ReadHistory(c.HistoryFile)
+	if err != nil {
+		return Response{}, err
+	}
+	now := c.now()
+	err = history.Check(day, part, answer, now)
+	if err != nil {
+		return Response{}, err
+	}
+
+	page, err := c.post(ctx, day, part, answer)
+	if err != nil {
+		return Response{}, err
+	}
+	response, err := ParseResponse(page)
+	if err != nil {
+		return Response{}, err
+	}
+
+	history.Days[day] = append(history.Days[day], Submission{Part: part, Answer: answer, Verdict: response.Verdict, Time: now})
+	if response.Wait > 0 {
+		history.WaitUntil = now.Add(response.Wait)
+	}
+
+	return response, WriteHistory(c.HistoryFile, history)
+}
+
+func (c *Client) post(ctx context.Context, day, part int, answer string) (string, error) {
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	address := fmt.Sprintf("%s/%d/day/%d/answer", strings.TrimSuffix(c.BaseURL, "/"), input.Year, day)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, address, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err //nolint:wrapcheck // Toy code
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("User-Agent", c.UserAgent)
+	request.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+
+	response, err := c.Client.Do(request)
+	if err != nil {
+		return "", err //nolint:wrapcheck // Toy code
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", err //nolint:wrapcheck // Toy code
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("submitting day %d part %d: %s", day, part, response.Status)
+	}
+
+	return string(data), nil
+}
+
+// AnswerFromReport picks an answer out of the JSON object a puzzle command writes with --output json: the one under key,
+// or the only one when key is empty.
+func AnswerFromReport(report io.Reader, key string) (string, error) {
+	var object struct {
+		Answers map[string]json.RawMessage `json:"answers"`
+	}
+	err := json.NewDecoder(report).Decode(&object)
+	if err != nil {
+		return "", fmt.Errorf("reading report: %w", err)
+	}
+
+	if key == "" {
+		if len(object.Answers) != 1 {
+			return "", fmt.Errorf("the report has %d answers, pick one with a key", len(object.Answers))
+		}
+		for only := range object.Answers {
+			key = only
+		}
+	}
+	raw, ok := object.Answers[key]
+	if !ok {
+		return "", fmt.Errorf("the report has no answer %q", key)
+	}
+
+	var text string
+	if json.Unmarshal(raw, &text) == nil {
+		return text, nil
+	}
+
+	return string(raw), nil
+}
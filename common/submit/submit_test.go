@@ -0,0 +1,153 @@
+package submit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func page(message string) string {
+	return "<html><body><main>\n<article><p>" + message + "</p></article>\n</main></body></html>"
+}
+
+// fakeSite checks answers the way the puzzle website does: day 1 part 1 is 42, and a wrong answer must be followed by a
+// minute of waiting.
+func fakeSite(t *testing.T, posted *[]string) *httptest.Server {
+	t.Helper()
+	var waitUntil time.Time
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /2024/day/{day}/answer", func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "secret" {
+			http.Error(w, "Unauthorized", http.StatusBadRequest)
+			return
+		}
+		answer := r.PostFormValue("answer")
+		*posted = append(*posted, fmt.Sprintf("%s/%s:%s", r.PathValue("day"), r.PostFormValue("level"), answer))
+
+		switch {
+		case time.Now().Before(waitUntil):
+			left := time.Until(waitUntil).Round(time.Second)
+			_, _ = w.Write([]byte(page(fmt.Sprintf("You gave an answer too recently; you have to wait after submitting an "+
+				"answer before trying again.  You have %dm %ds left to wait.", int(left.Minutes()), int(left.Seconds())%60))))
+		case answer == "42":
+			_, _ = w.Write([]byte(page(`That's the right answer!  You are <span class="day-success">one gold star</span>` +
+				` closer to finding the Chief Historian.`)))
+		case answer == "1000":
+			waitUntil = time.Now().Add(time.Minute)
+			_, _ = w.Write([]byte(page("That's not the right answer; your answer is too high.  If you're stuck, make sure " +
+				"you're using the full input data.  Please wait one minute before trying again.")))
+		default:
+			_, _ = w.Write([]byte(page("That's not the right answer.  Please wait one minute before trying again.")))
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestSubmit(t *testing.T) {
+	var posted []string
+	site := fakeSite(t, &posted)
+	client := NewClient("secret", filepath.Join(t.TempDir(), "submissions.json"))
+	client.BaseURL = site.URL
+	clock := time.Date(2024, 12, 1, 5, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return clock }
+
+	response, err := client.Submit(context.Background(), 1, 1, "1000")
+	require.NoError(t, err)
+	assert.Equal(t, TooHigh, response.Verdict)
+	assert.Equal(t, time.Minute, response.Wait)
+
+	_, err = client.Submit(context.Background(), 1, 1, "900")
+	require.EqualError(t, err, "not submitting: the website asked to wait 1m0s more")
+
+	clock = clock.Add(time.Minute)
+	_, err = client.Submit(context.Background(), 1, 1, "1000")
+	require.EqualError(t, err, "not submitting: 1000 was already found too high")
+	_, err = client.Submit(context.Background(), 1, 1, "1234")
+	require.EqualError(t, err, "not submitting: 1234 is not below 1000, which was too high")
+
+	// The stand-in site still wants a minute to pass in real time.
+	response, err = client.Submit(context.Background(), 1, 1, "42")
+	require.NoError(t, err)
+	assert.Equal(t, Throttled, response.Verdict)
+	assert.Positive(t, response.Wait)
+
+	history, err := ReadHistory(client.HistoryFile)
+	require.NoError(t, err)
+	assert.Equal(t, []Submission{
+		{Part: 1, Answer: "1000", Verdict: TooHigh, Time: clock.Add(-time.Minute)},
+		{Part: 1, Answer: "42", Verdict: Throttled, Time: clock},
+	}, history.Days[1])
+	assert.Equal(t, []string{"1/1:1000", "1/1:42"}, posted)
+}
+
+func TestCheck(t *testing.T) {
+	now := time.Date(2024, 12, 1, 5, 0, 0, 0, time.UTC)
+	history := History{Days: map[int][]Submission{
+		3: {
+			{Part: 1, Answer: "10", Verdict: TooLow},
+			{Part: 1, Answer: "abc", Verdict: Wrong},
+			{Part: 2, Answer: "7", Verdict: Correct},
+		},
+	}}
+
+	require.NoError(t, history.Check(3, 1, "11", now))
+	require.NoError(t, history.Check(4, 2, "7", now))
+	require.EqualError(t, history.Check(3, 1, "9", now), "not submitting: 9 is not above 10, which was too low")
+	require.EqualError(t, history.Check(3, 1, "abc", now), "not submitting: abc was already found wrong")
+	require.EqualError(t, history.Check(3, 2, "8", now), "not submitting: day 3 part 2 was already solved with 7")
+
+	history.WaitUntil = now.Add(90 * time.Second)
+	require.EqualError(t, history.Check(3, 1, "11", now), "not submitting: the website asked to wait 1m30s more")
+}
+
+func TestParseResponse(t *testing.T) {
+	response, err := ParseResponse(page("You gave an answer too recently; you have to wait after submitting an answer " +
+		"before trying again.  You have 4m 32s left to wait. <a href=\"/2024/day/1\">[Return to Day 1]</a>"))
+	require.NoError(t, err)
+	assert.Equal(t, Throttled, response.Verdict)
+	assert.Equal(t, 4*time.Minute+32*time.Second, response.Wait)
+
+	response, err = ParseResponse(page("That's not the right answer; your answer is too low.  Because you have guessed " +
+		"incorrectly 4 times on this puzzle, please wait 5 minutes before trying again."))
+	require.NoError(t, err)
+	assert.Equal(t, TooLow, response.Verdict)
+	assert.Equal(t, 5*time.Minute, response.Wait)
+
+	response, err = ParseResponse(page("You don't seem to be solving the right level.  Did you already complete it?"))
+	require.NoError(t, err)
+	assert.Equal(t, AlreadySolved, response.Verdict)
+	assert.Zero(t, response.Wait)
+
+	_, err = ParseResponse("<html>Internal error</html>")
+	require.Error(t, err)
+}
+
+func TestAnswerFromReport(t *testing.T) {
+	report := `{"answers":{"totalCost":29522},"parse":{"bytes":20981},"elapsedSeconds":0.001}`
+	answer, err := AnswerFromReport(strings.NewReader(report), "")
+	require.NoError(t, err)
+	assert.Equal(t, "29522", answer)
+
+	report = `{"answers":{"maxAttainable":1025129837448068,"runningTotal":637696070419031,"lastBlock":"6,36"}}`
+	answer, err = AnswerFromReport(strings.NewReader(report), "runningTotal")
+	require.NoError(t, err)
+	assert.Equal(t, "637696070419031", answer)
+	answer, err = AnswerFromReport(strings.NewReader(report), "lastBlock")
+	require.NoError(t, err)
+	assert.Equal(t, "6,36", answer)
+
+	_, err = AnswerFromReport(strings.NewReader(report), "")
+	require.EqualError(t, err, "the report has 3 answers, pick one with a key")
+}
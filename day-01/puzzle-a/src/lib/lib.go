@@ -0,0 +1,229 @@
+package lib
+
+import (
+	"bufio"
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+	"unicode"
+
+	"common/parsing"
+)
+
+// Metric compares the two location lists. Values are ints or float64s.
+type Metric struct {
+	Name        string
+	Description string
+	Compute     func(left, right []int) (any, error)
+}
+
+var Metrics = []Metric{ //nolint:gochecknoglobals // Meant as a constant
+	{
+		Name:        "distance",
+		Description: "sum of absolute differences between the sorted lists",
+		Compute:     func(left, right []int) (any, error) { return Distance(left, right) },
+	},
+	{
+		Name:        "similarity",
+		Description: "sum of left values weighted by their occurrences on the right",
+		Compute:     func(left, right []int) (any, error) { return Similarity(left, right), nil },
+	},
+	{
+		Name:        "emd",
+		Description: "earth mover's distance between the value distributions of both lists",
+		Compute:     func(left, right []int) (any, error) { return EarthMoversDistance(left, right) },
+	},
+	{
+		Name:        "jaccard",
+		Description: "multiset Jaccard index of both lists",
+		Compute:     func(left, right []int) (any, error) { return Jaccard(left, right), nil },
+	},
+	{
+		Name:        "unmatched",
+		Description: "number of values without a counterpart in the other list",
+		Compute:     func(left, right []int) (any, error) { return Unmatched(left, right), nil },
+	},
+}
+
+func MetricByName(name string) (Metric, bool) {
+	for _, metric := range Metrics {
+		if metric.Name == name {
+			return metric, true
+		}
+	}
+	return Metric{}, false
+}
+
+func AbsDiff(a, b int) int {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// Distance pairs up the smallest values of both lists, then the next smallest and so on, summing the differences.
+func Distance(left, right []int) (int, error) {
+	if len(left) != len(right) {
+		return 0, errors.New("lists are not of the same length")
+	}
+	left = slices.Sorted(slices.Values(left))
+	right = slices.Sorted(slices.Values(right))
+
+	distance := 0
+	for i := range left {
+		distance += AbsDiff(left[i], right[i])
+	}
+	return distance, nil
+}
+
+func Similarity(left, right []int) int {
+	valCounts := counts(right)
+	total := 0
+	for _, v := range left {
+		total += v * valCounts[v]
+	}
+	return total
+}
+
+// EarthMoversDistance treats both lists as distributions of unit total mass and returns the least mass times distance
+// needed to turn one into the other. For lists of equal length this is Distance divided by the length.
+func EarthMoversDistance(left, right []int) (float64, error) {
+	if len(left) == 0 || len(right) == 0 {
+		return 0, errors.New("lists must not be empty")
+	}
+	leftCounts := counts(left)
+	rightCounts := counts(right)
+	values := slices.Sorted(maps.Keys(leftCounts))
+	values = append(values, slices.Collect(maps.Keys(rightCounts))...)
+	slices.Sort(values)
+	values = slices.Compact(values)
+
+	// Integrate the difference between both cumulative distributions.
+	emd := 0.0
+	leftSeen, rightSeen := 0, 0
+	for i, v := range values[:len(values)-1] {
+		leftSeen += leftCounts[v]
+		rightSeen += rightCounts[v]
+		gap := float64(leftSeen)/float64(len(left)) - float64(rightSeen)/float64(len(right))
+		if gap < 0 {
+			gap = -gap
+		}
+		emd += gap * float64(values[i+1]-v)
+	}
+	return emd, nil
+}
+
+// Jaccard divides the size of the multiset intersection by the size of the multiset union. Two empty lists are
+// identical.
+func Jaccard(left, right []int) float64 {
+	leftCounts := counts(left)
+	rightCounts := counts(right)
+	intersection, union := 0, 0
+	for v, leftCount := range leftCounts {
+		rightCount := rightCounts[v]
+		intersection += min(leftCount, rightCount)
+		union += max(leftCount, rightCount)
+	}
+	for v, rightCount := range rightCounts {
+		if _, found := leftCounts[v]; !found {
+			union += rightCount
+		}
+	}
+	if union == 0 {
+		return 1
+	}
+	return float64(intersection) / float64(union)
+}
+
+// Unmatched counts the values left over on either side after matching equal values one to one.
+func Unmatched(left, right []int) int {
+	leftCounts := counts(left)
+	rightCounts := counts(right)
+	unmatched := 0
+	for v, leftCount := range leftCounts {
+		unmatched += AbsDiff(leftCount, rightCounts[v])
+	}
+	for v, rightCount := range rightCounts {
+		if _, found := leftCounts[v]; !found {
+			unmatched += rightCount
+		}
+	}
+	return unmatched
+}
+
+func counts(values []int) map[int]int {
+	valCounts := make(map[int]int)
+	for _, v := range values {
+		valCounts[v]++
+	}
+	return valCounts
+}
+
+// ReadPairs calls yield with both location IDs of every line, skipping blank lines. Any other line that is not made of
+// two numbers is reported as a parsing.Error.
+func ReadPairs(scanner *bufio.Scanner, yield func(num1, num2 int) error) error {
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		fields, cols := fieldColumns(line)
+		switch {
+		case len(fields) == 0:
+			continue
+		case len(fields) < 2: //nolint:mnd // One number per list
+			return parsing.Errorf(lineNum, len(line)+1, "", "expected 2 numbers, got %d", len(fields))
+		case len(fields) > 2: //nolint:mnd // One number per list
+			return parsing.Errorf(lineNum, cols[2], line[cols[2]-1:], "expected 2 numbers, got %d", len(fields))
+		}
+
+		var nums [2]int
+		for iField, field := range fields {
+			num, err := strconv.Atoi(field)
+			if err != nil {
+				return parsing.Errorf(lineNum, cols[iField], field, "expected a number")
+			}
+			nums[iField] = num
+		}
+		err := yield(nums[0], nums[1])
+		if err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err() //nolint:wrapcheck // Toy code
+}
+
+// ReadInput reads the left and the right list of location IDs.
+func ReadInput(scanner *bufio.Scanner) ([]int, []int, error) {
+	var left, right []int
+	err := ReadPairs(scanner, func(num1, num2 int) error {
+		left = append(left, num1)
+		right = append(right, num2)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return left, right, nil
+}
+
+// fieldColumns splits the line around runs of white space, like strings.Fields, also returning the 1-based column at
+// which every field starts.
+func fieldColumns(line string) ([]string, []int) {
+	var fields []string
+	var cols []int
+	start := -1
+	for iByte, char := range line + " " {
+		switch {
+		case unicode.IsSpace(char) && start >= 0:
+			fields = append(fields, line[start:iByte])
+			cols = append(cols, start+1)
+			start = -1
+		case !unicode.IsSpace(char) && start < 0:
+			start = iByte
+		}
+	}
+	return fields, cols
+}
@@ -0,0 +1,159 @@
+package lib
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"testing"
+
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	tests := []struct {
+		name        string
+		left        []int
+		right       []int
+		distance    int
+		similarity  int
+		emd         float64
+		jaccard     float64
+		unmatched   int
+		notSameSize bool
+	}{
+		{
+			// Intersection {3, 3, 3, 4}, union {1, 2, 3, 3, 3, 4, 5, 9}.
+			name:       "example",
+			left:       []int{3, 4, 2, 1, 3, 3},
+			right:      []int{4, 3, 5, 3, 9, 3},
+			distance:   2 + 1 + 0 + 1 + 2 + 5,
+			similarity: 3*3 + 4*1 + 2*0 + 1*0 + 3*3 + 3*3,
+			emd:        11.0 / 6,
+			jaccard:    4.0 / 8,
+			unmatched:  4,
+		},
+		{
+			name:       "disjoint",
+			left:       []int{1, 2},
+			right:      []int{5, 5},
+			distance:   4 + 3,
+			similarity: 0,
+			emd:        3.5,
+			jaccard:    0,
+			unmatched:  4,
+		},
+		{
+			name:       "identical",
+			left:       []int{-7, 2, 2},
+			right:      []int{2, -7, 2},
+			distance:   0,
+			similarity: -7*1 + 2*2 + 2*2,
+			emd:        0,
+			jaccard:    1,
+			unmatched:  0,
+		},
+		{
+			// Half of the right mass is 2 further away than the left mass.
+			name:        "not the same size",
+			left:        []int{1},
+			right:       []int{1, 3},
+			similarity:  1,
+			emd:         1,
+			jaccard:     1.0 / 2,
+			unmatched:   1,
+			notSameSize: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			distance, err := Distance(test.left, test.right)
+			if test.notSameSize {
+				require.EqualError(t, err, "lists are not of the same length")
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.distance, distance)
+			}
+			assert.Equal(t, test.similarity, Similarity(test.left, test.right))
+			emd, err := EarthMoversDistance(test.left, test.right)
+			require.NoError(t, err)
+			assert.InDelta(t, test.emd, emd, 1e-9)
+			assert.InDelta(t, test.jaccard, Jaccard(test.left, test.right), 1e-9)
+			assert.Equal(t, test.unmatched, Unmatched(test.left, test.right))
+		})
+	}
+}
+
+func TestMetricsEmpty(t *testing.T) {
+	distance, err := Distance(nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, distance)
+	assert.Equal(t, 0, Similarity(nil, nil))
+	_, err = EarthMoversDistance(nil, []int{1})
+	require.EqualError(t, err, "lists must not be empty")
+	assert.InDelta(t, 1, Jaccard(nil, nil), 0)
+	assert.InDelta(t, 0, Jaccard(nil, []int{1}), 0)
+	assert.Equal(t, 0, Unmatched(nil, nil))
+	assert.Equal(t, 2, Unmatched([]int{1, 1}, nil))
+}
+
+func TestDistanceKeepsOrder(t *testing.T) {
+	left := []int{3, 1, 2}
+	_, err := Distance(left, []int{1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 1, 2}, left)
+}
+
+func TestMetricByName(t *testing.T) {
+	for _, metric := range Metrics {
+		found, ok := MetricByName(metric.Name)
+		require.True(t, ok, metric.Name)
+		assert.Equal(t, metric.Description, found.Description)
+	}
+	_, ok := MetricByName("cosine")
+	assert.False(t, ok)
+
+	emd, ok := MetricByName("emd")
+	require.True(t, ok)
+	value, err := emd.Compute([]int{1, 2}, []int{5, 5})
+	require.NoError(t, err)
+	assert.InDelta(t, 3.5, value, 1e-9)
+}
+
+func readLists(input string) ([]int, []int, error) {
+	return ReadInput(bufio.NewScanner(strings.NewReader(input)))
+}
+
+// printLists writes the lists back out in the puzzle format.
+func printLists(left, right []int) string {
+	var builder strings.Builder
+	for iPair := range left {
+		fmt.Fprintf(&builder, "%d   %d\n", left[iPair], right[iPair])
+	}
+	return builder.String()
+}
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("3   4\n4   3\n2   5\n1   3\n3   9\n3   3\n")
+	f.Add("\n  -7\t+2\n\n")
+	f.Add("1\n")
+	f.Add("1 2 3\n")
+	f.Add("1 x\n")
+	f.Add("99999999999999999999 1\n")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, input string) {
+		left, right, err := readLists(input)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+		require.Len(t, right, len(left))
+
+		reread, rereadRight, err := readLists(printLists(left, right))
+		require.NoError(t, err)
+		assert.Equal(t, left, reread)
+		assert.Equal(t, right, rereadRight)
+	})
+}
@@ -0,0 +1,425 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"container/heap"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"sort"
+
+	"common/command"
+	"common/report"
+	"dayone/a/lib"
+)
+
+type Args struct {
+	command.Flags
+
+	External  bool     `arg:"--external"        help:"sort through temporary files, for lists larger than memory; also reports the similarity score"`
+	ChunkSize int      `arg:"--chunk-size"      default:"1048576" help:"values per column held in memory while sorting with --external"`
+	TempDir   string   `arg:"--temp-dir"        help:"directory for the temporary files of --external (default: system temp dir)"`
+	FanIn     int      `arg:"--fan-in"          default:"64" help:"most temporary files merged at once per column with --external"`
+	Metrics   []string `arg:"--metric,separate" help:"write this metric to stdout as JSON (repeatable; distance, similarity, emd, jaccard, unmatched or all)"`
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(_ context.Context, args Args, input io.Reader, out *report.Report) error {
+	if args.External && len(args.Metrics) > 0 {
+		return errors.New("--metric needs the lists in memory and cannot be combined with --external")
+	}
+	metrics, err := selectMetrics(args.Metrics)
+	if err != nil {
+		return err
+	}
+
+	if args.External {
+		distance, similarity, err := externalSolve(out.CountInput(input), args)
+		if err != nil {
+			return err //nolint:wrapcheck // Toy code
+		}
+		slog.Info("solved", "distance", distance, "similarity", similarity)
+		out.Answer("distance", distance)
+		out.Answer("similarity", similarity)
+		return nil
+	}
+
+	slice1, slice2, err := lib.ReadInput(bufio.NewScanner(out.CountInput(input)))
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+	out.Parsed("pairs", len(slice1))
+
+	if len(metrics) > 0 {
+		values, err := computeMetrics(metrics, slice1, slice2)
+		if err != nil {
+			return err
+		}
+		if out.IsJSON() {
+			for name, value := range values {
+				out.Answer(name, value)
+			}
+			return nil
+		}
+		return writeMetrics(os.Stdout, values)
+	}
+
+	distance, err := lib.Distance(slice1, slice2)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	slog.Info("solved", "distance", distance)
+	out.Answer("distance", distance)
+
+	return nil
+}
+
+func selectMetrics(names []string) ([]lib.Metric, error) {
+	if slices.Contains(names, "all") {
+		return lib.Metrics, nil
+	}
+	metrics := make([]lib.Metric, 0, len(names))
+	for _, name := range names {
+		metric, found := lib.MetricByName(name)
+		if !found {
+			return nil, fmt.Errorf("unknown metric %q", name)
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics, nil
+}
+
+// computeMetrics maps every metric's name to its value.
+func computeMetrics(metrics []lib.Metric, left, right []int) (map[string]any, error) {
+	values := make(map[string]any, len(metrics))
+	for _, metric := range metrics {
+		value, err := metric.Compute(left, right)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", metric.Name, err)
+		}
+		values[metric.Name] = value
+	}
+
+	return values, nil
+}
+
+// writeMetrics writes a JSON object mapping every metric's name to its value.
+func writeMetrics(w io.Writer, values map[string]any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(values) //nolint:wrapcheck // Toy code
+}
+
+// externalSolve sorts both columns in chunks of at most args.ChunkSize values, spilling every sorted chunk to a
+// temporary file. The input is read only once. The chunks of each column are then merged, at most args.FanIn files at a
+// time, until a single merge can go through all of them; that last merge sweeps both columns in value order, computing
+// the distance and the similarity score together.
+func externalSolve(r io.Reader, args Args) (int, int, error) {
+	if args.ChunkSize < 1 {
+		return 0, 0, fmt.Errorf("invalid chunk size %d", args.ChunkSize)
+	}
+	if args.FanIn < 2 { //nolint:mnd // Merging fewer files at a time never ends
+		return 0, 0, fmt.Errorf("invalid fan-in %d", args.FanIn)
+	}
+	dir, err := os.MkdirTemp(args.TempDir, "day-01-")
+	if err != nil {
+		return 0, 0, err //nolint:wrapcheck // Toy code
+	}
+	defer os.RemoveAll(dir)
+
+	left := &chunkWriter{dir: dir, size: args.ChunkSize}
+	right := &chunkWriter{dir: dir, size: args.ChunkSize}
+	err = lib.ReadPairs(bufio.NewScanner(r), func(num1, num2 int) error {
+		return errors.Join(left.add(num1), right.add(num2))
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	err = errors.Join(left.flush(), right.flush())
+	if err != nil {
+		return 0, 0, err
+	}
+
+	leftFiles, err := reduceChunks(dir, left.files, args.FanIn)
+	if err != nil {
+		return 0, 0, err
+	}
+	rightFiles, err := reduceChunks(dir, right.files, args.FanIn)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	leftMerger, err := openMerger(leftFiles)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer leftMerger.close()
+	rightMerger, err := openMerger(rightFiles)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer rightMerger.close()
+
+	return sweep(leftMerger, rightMerger)
+}
+
+// sweep goes once through the distinct values of both columns in ascending order. The similarity score adds up every
+// value times its number of occurrences in both columns. The distance between the i-th smallest values of both
+// columns adds up to the area between the two step functions counting the values up to x in every column, which only
+// needs the counts seen so far.
+func sweep(left, right *merger) (int, int, error) {
+	distance, similarity := 0, 0
+	leftSeen, rightSeen := 0, 0
+	previous := 0
+	for {
+		value1, ok1 := left.peek()
+		value2, ok2 := right.peek()
+		if !ok1 && !ok2 {
+			break
+		}
+		value := value1
+		if !ok1 || ok2 && value2 < value1 {
+			value = value2
+		}
+
+		if leftSeen > 0 || rightSeen > 0 {
+			distance += lib.AbsDiff(leftSeen, rightSeen) * (value - previous)
+		}
+		nLeft, err1 := left.skip(value)
+		nRight, err2 := right.skip(value)
+		if err := errors.Join(err1, err2); err != nil {
+			return 0, 0, err
+		}
+		leftSeen += nLeft
+		rightSeen += nRight
+		similarity += value * nLeft * nRight
+		previous = value
+	}
+	if leftSeen != rightSeen {
+		return 0, 0, errors.New("columns are not of the same length")
+	}
+
+	return distance, similarity, nil
+}
+
+// reduceChunks merges the sorted chunk files fanIn at a time into longer ones, until at most fanIn are left.
+func reduceChunks(dir string, paths []string, fanIn int) ([]string, error) {
+	for len(paths) > fanIn {
+		merged := make([]string, 0, (len(paths)+fanIn-1)/fanIn)
+		for start := 0; start < len(paths); start += fanIn {
+			path, err := mergeChunks(dir, paths[start:min(start+fanIn, len(paths))])
+			if err != nil {
+				return nil, err
+			}
+			merged = append(merged, path)
+		}
+		paths = merged
+	}
+
+	return paths, nil
+}
+
+// mergeChunks merges sorted chunk files into a new one, removing them.
+func mergeChunks(dir string, paths []string) (string, error) {
+	m, err := openMerger(paths)
+	if err != nil {
+		return "", err
+	}
+	path, err := writeChunk(dir, m.next)
+	m.close()
+	if err != nil {
+		return "", err
+	}
+
+	for _, merged := range paths {
+		err = errors.Join(err, os.Remove(merged))
+	}
+
+	return path, err
+}
+
+// writeChunk writes the values returned by next to a new chunk file, until next runs out of them.
+func writeChunk(dir string, next func() (int, bool, error)) (string, error) {
+	file, err := os.CreateTemp(dir, "chunk-")
+	if err != nil {
+		return "", err //nolint:wrapcheck // Toy code
+	}
+	w := bufio.NewWriter(file)
+	for {
+		value, ok, nextErr := next()
+		if nextErr != nil || !ok {
+			err = nextErr
+			break
+		}
+		err = binary.Write(w, binary.LittleEndian, int64(value))
+		if err != nil {
+			break
+		}
+	}
+	err = errors.Join(err, w.Flush(), file.Close())
+	if err != nil {
+		return "", err
+	}
+
+	return file.Name(), nil
+}
+
+// chunkWriter buffers values and writes them out as sorted chunk files.
+type chunkWriter struct {
+	dir   string
+	size  int
+	buf   []int
+	files []string
+}
+
+func (c *chunkWriter) add(value int) error {
+	c.buf = append(c.buf, value)
+	if len(c.buf) < c.size {
+		return nil
+	}
+	return c.flush()
+}
+
+func (c *chunkWriter) flush() error {
+	if len(c.buf) == 0 {
+		return nil
+	}
+	sort.Ints(c.buf)
+
+	iValue := 0
+	path, err := writeChunk(c.dir, func() (int, bool, error) {
+		if iValue == len(c.buf) {
+			return 0, false, nil
+		}
+		iValue++
+		return c.buf[iValue-1], true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.files = append(c.files, path)
+	c.buf = c.buf[:0]
+	return nil
+}
+
+// chunkReader streams the values of one sorted chunk file, with head holding the smallest value not yet merged.
+type chunkReader struct {
+	file *os.File
+	r    *bufio.Reader
+	head int
+}
+
+func (c *chunkReader) advance() (bool, error) {
+	var value int64
+	err := binary.Read(c.r, binary.LittleEndian, &value)
+	if errors.Is(err, io.EOF) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err //nolint:wrapcheck // Toy code
+	}
+	c.head = int(value)
+	return true, nil
+}
+
+type chunkHeap []*chunkReader
+
+func (h chunkHeap) Len() int           { return len(h) }
+func (h chunkHeap) Less(i, j int) bool { return h[i].head < h[j].head }
+func (h chunkHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *chunkHeap) Push(x any)        { *h = append(*h, x.(*chunkReader)) } //nolint:forcetypeassert // Only readers are pushed
+func (h *chunkHeap) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
+
+// merger yields the values of several sorted chunk files in ascending order.
+type merger struct {
+	heap  chunkHeap
+	files []*os.File
+}
+
+func openMerger(paths []string) (*merger, error) {
+	m := &merger{}
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			m.close()
+			return nil, err //nolint:wrapcheck // Toy code
+		}
+		m.files = append(m.files, file)
+
+		reader := &chunkReader{file: file, r: bufio.NewReader(file)}
+		ok, err := reader.advance()
+		if err != nil {
+			m.close()
+			return nil, err
+		}
+		if ok {
+			m.heap = append(m.heap, reader)
+		}
+	}
+	heap.Init(&m.heap)
+
+	return m, nil
+}
+
+// peek returns the smallest value not yet merged, without merging it.
+func (m *merger) peek() (int, bool) {
+	if len(m.heap) == 0 {
+		return 0, false
+	}
+	return m.heap[0].head, true
+}
+
+// skip merges every occurrence of the value, which must be the smallest not yet merged, and returns their number.
+func (m *merger) skip(value int) (int, error) {
+	count := 0
+	for head, ok := m.peek(); ok && head == value; head, ok = m.peek() {
+		_, _, err := m.next()
+		if err != nil {
+			return 0, err
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+func (m *merger) next() (int, bool, error) {
+	if len(m.heap) == 0 {
+		return 0, false, nil
+	}
+	reader := m.heap[0]
+	value := reader.head
+	ok, err := reader.advance()
+	if err != nil {
+		return 0, false, err
+	}
+	if ok {
+		heap.Fix(&m.heap, 0)
+	} else {
+		heap.Pop(&m.heap)
+	}
+	return value, true, nil
+}
+
+func (m *merger) close() {
+	for _, file := range m.files {
+		err := file.Close()
+		if err != nil {
+			slog.Warn("could not close run file", "err", err)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package solver
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"dayone/a/lib"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readChunk returns the values of a chunk file.
+func readChunk(t *testing.T, path string) []int {
+	t.Helper()
+	m, err := openMerger([]string{path})
+	require.NoError(t, err)
+	defer m.close()
+	return drain(t, m)
+}
+
+func drain(t *testing.T, m *merger) []int {
+	t.Helper()
+	var values []int
+	for {
+		value, ok, err := m.next()
+		require.NoError(t, err)
+		if !ok {
+			return values
+		}
+		values = append(values, value)
+	}
+}
+
+func TestChunkWriter(t *testing.T) {
+	writer := &chunkWriter{dir: t.TempDir(), size: 3}
+	for _, value := range []int{5, -1, 3, 3, 0, 9, 2} {
+		require.NoError(t, writer.add(value))
+	}
+	require.Len(t, writer.files, 2)
+	require.NoError(t, writer.flush())
+	require.Len(t, writer.files, 3)
+	// Flushing an empty buffer writes nothing.
+	require.NoError(t, writer.flush())
+	require.Len(t, writer.files, 3)
+
+	assert.Equal(t, []int{-1, 3, 5}, readChunk(t, writer.files[0]))
+	assert.Equal(t, []int{0, 3, 9}, readChunk(t, writer.files[1]))
+	assert.Equal(t, []int{2}, readChunk(t, writer.files[2]))
+}
+
+func TestMerger(t *testing.T) {
+	writer := &chunkWriter{dir: t.TempDir(), size: 4}
+	values := []int{7, 1, 4, 4, 2, 8, 1, 0, 9, 4}
+	for _, value := range values {
+		require.NoError(t, writer.add(value))
+	}
+	require.NoError(t, writer.flush())
+
+	m, err := openMerger(writer.files)
+	require.NoError(t, err)
+	defer m.close()
+	head, ok := m.peek()
+	require.True(t, ok)
+	assert.Equal(t, 0, head)
+	count, err := m.skip(0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	count, err = m.skip(1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []int{2, 4, 4, 4, 7, 8, 9}, drain(t, m))
+	_, ok = m.peek()
+	assert.False(t, ok)
+}
+
+func TestReduceChunks(t *testing.T) {
+	dir := t.TempDir()
+	writer := &chunkWriter{dir: dir, size: 1}
+	values := rand.New(rand.NewPCG(1, 2)).Perm(50)
+	for _, value := range values {
+		require.NoError(t, writer.add(value))
+	}
+
+	paths, err := reduceChunks(dir, writer.files, 3)
+	require.NoError(t, err)
+	// 50 files, then 17, 6 and 2.
+	require.Len(t, paths, 2)
+	var merged []int
+	for _, path := range paths {
+		chunk := readChunk(t, path)
+		assert.True(t, slices.IsSorted(chunk))
+		merged = append(merged, chunk...)
+	}
+	assert.ElementsMatch(t, values, merged)
+
+	// The merged files are removed along the way.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestExternalSolve(t *testing.T) {
+	committed, err := os.ReadFile("../../input/input.txt")
+	require.NoError(t, err)
+
+	rng := rand.New(rand.NewPCG(3, 4))
+	var generated strings.Builder
+	for range 500 {
+		// Few distinct values, some of them negative, so that many values repeat on both sides.
+		fmt.Fprintf(&generated, "%d   %d\n", rng.IntN(40)-10, rng.IntN(40)-10)
+	}
+
+	inputs := map[string]string{
+		"example":   "3   4\n4   3\n2   5\n1   3\n3   9\n3   3\n",
+		"committed": string(committed),
+		"generated": generated.String(),
+		"single":    "5   7\n",
+		"empty":     "",
+	}
+	for name, input := range inputs {
+		left, right, err := lib.ReadInput(bufio.NewScanner(strings.NewReader(input)))
+		require.NoError(t, err)
+		distance, err := lib.Distance(left, right)
+		require.NoError(t, err)
+		similarity := lib.Similarity(left, right)
+
+		for _, chunkSize := range []int{1, 7, 1 << 20} {
+			for _, fanIn := range []int{2, 5, 64} {
+				tempDir := t.TempDir()
+				args := Args{ChunkSize: chunkSize, FanIn: fanIn, TempDir: tempDir}
+				externalDistance, externalSimilarity, err := externalSolve(bytes.NewReader([]byte(input)), args)
+				require.NoError(t, err)
+				assert.Equal(t, distance, externalDistance, "%s with %+v", name, args)
+				assert.Equal(t, similarity, externalSimilarity, "%s with %+v", name, args)
+
+				leftovers, err := filepath.Glob(filepath.Join(tempDir, "*"))
+				require.NoError(t, err)
+				assert.Empty(t, leftovers)
+			}
+		}
+	}
+
+	_, _, err = externalSolve(strings.NewReader(""), Args{ChunkSize: 1, FanIn: 1})
+	assert.EqualError(t, err, "invalid fan-in 1")
+	_, _, err = externalSolve(strings.NewReader(""), Args{ChunkSize: 0, FanIn: 2})
+	assert.EqualError(t, err, "invalid chunk size 0")
+}
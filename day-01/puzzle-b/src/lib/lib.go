@@ -0,0 +1,77 @@
+package lib
+
+import (
+	"bufio"
+	"strconv"
+	"unicode"
+
+	"common/parsing"
+)
+
+// ReadPairs calls yield with both location IDs of every line, skipping blank lines. Any other line that is not made of
+// two numbers is reported as a parsing.Error.
+func ReadPairs(scanner *bufio.Scanner, yield func(num1, num2 int) error) error {
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		fields, cols := fieldColumns(line)
+		switch {
+		case len(fields) == 0:
+			continue
+		case len(fields) < 2: //nolint:mnd // One number per list
+			return parsing.Errorf(lineNum, len(line)+1, "", "expected 2 numbers, got %d", len(fields))
+		case len(fields) > 2: //nolint:mnd // One number per list
+			return parsing.Errorf(lineNum, cols[2], line[cols[2]-1:], "expected 2 numbers, got %d", len(fields))
+		}
+
+		var nums [2]int
+		for iField, field := range fields {
+			num, err := strconv.Atoi(field)
+			if err != nil {
+				return parsing.Errorf(lineNum, cols[iField], field, "expected a number")
+			}
+			nums[iField] = num
+		}
+		err := yield(nums[0], nums[1])
+		if err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err() //nolint:wrapcheck // Toy code
+}
+
+// ReadInput reads the left and the right list of location IDs.
+func ReadInput(scanner *bufio.Scanner) ([]int, []int, error) {
+	var left, right []int
+	err := ReadPairs(scanner, func(num1, num2 int) error {
+		left = append(left, num1)
+		right = append(right, num2)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return left, right, nil
+}
+
+// fieldColumns splits the line around runs of white space, like strings.Fields, also returning the 1-based column at
+// which every field starts.
+func fieldColumns(line string) ([]string, []int) {
+	var fields []string
+	var cols []int
+	start := -1
+	for iByte, char := range line + " " {
+		switch {
+		case unicode.IsSpace(char) && start >= 0:
+			fields = append(fields, line[start:iByte])
+			cols = append(cols, start+1)
+			start = -1
+		case !unicode.IsSpace(char) && start < 0:
+			start = iByte
+		}
+	}
+	return fields, cols
+}
@@ -0,0 +1,49 @@
+package lib
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"testing"
+
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readLists(input string) ([]int, []int, error) {
+	return ReadInput(bufio.NewScanner(strings.NewReader(input)))
+}
+
+// printLists writes the lists back out in the puzzle format.
+func printLists(left, right []int) string {
+	var builder strings.Builder
+	for iPair := range left {
+		fmt.Fprintf(&builder, "%d   %d\n", left[iPair], right[iPair])
+	}
+	return builder.String()
+}
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("3   4\n4   3\n2   5\n1   3\n3   9\n3   3\n")
+	f.Add("\n  -7\t+2\n\n")
+	f.Add("1\n")
+	f.Add("1 2 3\n")
+	f.Add("1 x\n")
+	f.Add("99999999999999999999 1\n")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, input string) {
+		left, right, err := readLists(input)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+		require.Len(t, right, len(left))
+
+		reread, rereadRight, err := readLists(printLists(left, right))
+		require.NoError(t, err)
+		assert.Equal(t, left, reread)
+		assert.Equal(t, right, rereadRight)
+	})
+}
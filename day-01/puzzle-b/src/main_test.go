@@ -0,0 +1,18 @@
+package main
+
+import (
+	"testing"
+
+	"common/command/commandtest"
+	"dayone/b/solver"
+)
+
+// BenchmarkSolve runs the puzzle end to end on the committed input.
+func BenchmarkSolve(b *testing.B) {
+	commandtest.Benchmark(b, main, "../input/input.txt")
+}
+
+// TestExamples checks the answers to the examples of the puzzle statement.
+func TestExamples(t *testing.T) {
+	commandtest.Examples(t, exampleFS, solver.Solve)
+}
@@ -0,0 +1,43 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"dayone/b/lib"
+)
+
+type Args struct {
+	command.Flags
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(_ context.Context, args Args, input io.Reader, out *report.Report) error {
+	slice1, slice2, err := lib.ReadInput(bufio.NewScanner(out.CountInput(input)))
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	out.Parsed("pairs", len(slice1))
+
+	valCounts := make(map[int]int)
+	for i := range slice2 {
+		valCounts[slice2[i]]++
+	}
+
+	total := 0
+	for _, v := range slice1 {
+		nOccurrences := valCounts[v]
+		total += v * nOccurrences
+	}
+
+	slog.Info("solved", "similarity", total)
+	out.Answer("similarity", total)
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package lib
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+	"testing"
+
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readReports(input string) ([][]int, error) {
+	return ReadInput(bufio.NewScanner(strings.NewReader(input)))
+}
+
+// printReports writes the reports back out in the puzzle format.
+func printReports(reports [][]int) string {
+	var builder strings.Builder
+	for _, levels := range reports {
+		for iLevel, level := range levels {
+			if iLevel > 0 {
+				builder.WriteByte(' ')
+			}
+			builder.WriteString(strconv.Itoa(level))
+		}
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("7 6 4 2 1\n1 2 7 8 9\n9 7 6 2 1\n1 3 2 4 5\n8 6 4 4 1\n1 3 6 7 9\n")
+	f.Add("\n  -3\t+4 5\n\n")
+	f.Add("1 2 x\n")
+	f.Add("99999999999999999999\n")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, input string) {
+		reports, err := readReports(input)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+		for _, levels := range reports {
+			require.NotEmpty(t, levels)
+		}
+
+		reread, err := readReports(printReports(reports))
+		require.NoError(t, err)
+		assert.Equal(t, reports, reread)
+	})
+}
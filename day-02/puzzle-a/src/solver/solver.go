@@ -0,0 +1,67 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"daytwo/a/lib"
+	"golang.org/x/exp/constraints"
+)
+
+func Abs[T constraints.Signed](x T) T { //nolint:ireturn // false positive
+	return max(x, -x)
+}
+
+type Args struct {
+	command.Flags
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(_ context.Context, args Args, input io.Reader, out *report.Report) error {
+	reports, err := lib.ReadInput(bufio.NewScanner(out.CountInput(input)))
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+	out.Parsed("reports", len(reports))
+
+	nSafe := 0
+	for _, values := range reports {
+		nValues := len(values)
+
+		ascending := false
+		descending := false
+		legal := true
+		for i := range nValues - 1 {
+			step := values[i+1] - values[i]
+			if step > 0 {
+				ascending = true
+			} else if step < 0 {
+				descending = true
+			}
+			if ascending && descending {
+				legal = false
+				break
+			}
+
+			absStep := Abs(step)
+			if (absStep < 1) || (absStep > 3) {
+				legal = false
+				break
+			}
+		}
+
+		if legal {
+			nSafe++
+		}
+	}
+
+	slog.Info("solved", "safeReports", nSafe)
+	out.Answer("safeReports", nSafe)
+
+	return nil
+}
@@ -1,10 +1,20 @@
-module main
+module daytwo/b
 
-go 1.23.3
+go 1.23.4
 
 require (
-	github.com/samber/lo v1.47.0
+	github.com/alexflint/go-arg v1.6.1 // indirect
+	github.com/stretchr/testify v1.10.0
 	golang.org/x/exp v0.0.0-20241108190413-2d47ceb2692f
 )
 
-require golang.org/x/text v0.16.0 // indirect
+require (
+	github.com/alexflint/go-scalar v1.2.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+require common v0.0.0-00010101000000-000000000000
+
+replace common => ../../../common
@@ -0,0 +1,56 @@
+package lib
+
+import (
+	"bufio"
+	"strconv"
+	"unicode"
+
+	"common/parsing"
+)
+
+// ReadInput reads one report per line, as a list of levels, skipping blank lines.
+func ReadInput(scanner *bufio.Scanner) ([][]int, error) {
+	var reports [][]int
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		fields, cols := fieldColumns(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		levels := make([]int, len(fields))
+		for iField, field := range fields {
+			level, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, parsing.Errorf(lineNum, cols[iField], field, "expected a level")
+			}
+			levels[iField] = level
+		}
+		reports = append(reports, levels)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+
+	return reports, nil
+}
+
+// fieldColumns splits the line around runs of white space, like strings.Fields, also returning the 1-based column at
+// which every field starts.
+func fieldColumns(line string) ([]string, []int) {
+	var fields []string
+	var cols []int
+	start := -1
+	for iByte, char := range line + " " {
+		switch {
+		case unicode.IsSpace(char) && start >= 0:
+			fields = append(fields, line[start:iByte])
+			cols = append(cols, start+1)
+			start = -1
+		case !unicode.IsSpace(char) && start < 0:
+			start = iByte
+		}
+	}
+	return fields, cols
+}
@@ -0,0 +1,93 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"daytwo/b/lib"
+	"golang.org/x/exp/constraints"
+)
+
+func Abs[T constraints.Signed](x T) T { //nolint:ireturn // false positive
+	return max(x, -x)
+}
+
+type Args struct {
+	command.Flags
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(_ context.Context, args Args, input io.Reader, out *report.Report) error {
+	reports, err := lib.ReadInput(bufio.NewScanner(out.CountInput(input)))
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+	out.Parsed("reports", len(reports))
+
+	nSafe := 0
+	for _, values := range reports {
+		nValues := len(values)
+
+		legal, failIdx := isLegal(values, -1)
+		if legal {
+			nSafe++
+			continue
+		}
+
+		startCheck := max(0, failIdx-1)
+		endCheck := min(nValues-1, failIdx+1)
+		for idxToSkip := startCheck; idxToSkip <= endCheck; idxToSkip++ {
+			legal, _ := isLegal(values, idxToSkip)
+			if legal {
+				nSafe++
+				break
+			}
+		}
+	}
+
+	slog.Info("solved", "safeReports", nSafe)
+	out.Answer("safeReports", nSafe)
+
+	return nil
+}
+
+func isLegal(values []int, skipIdx int) (bool, int) {
+	ascending := false
+	descending := false
+	for idx := 0; idx < len(values)-1; idx++ {
+		if idx == skipIdx {
+			idx++
+		}
+
+		nextIdx := idx + 1
+		if nextIdx == skipIdx {
+			nextIdx++
+		}
+
+		if nextIdx >= len(values) {
+			break
+		}
+
+		step := values[nextIdx] - values[idx]
+		if step > 0 {
+			ascending = true
+		} else if step < 0 {
+			descending = true
+		}
+		if ascending && descending {
+			return false, idx
+		}
+
+		absStep := Abs(step)
+		if (absStep < 1) || (absStep > 3) {
+			return false, idx
+		}
+	}
+
+	return true, -1
+}
@@ -0,0 +1,45 @@
+package lib
+
+import (
+	"bufio"
+	"regexp"
+	"strconv"
+
+	"common/parsing"
+)
+
+// Mul is a `mul(X,Y)` instruction found in the corrupted memory.
+type Mul struct {
+	X int64
+	Y int64
+}
+
+var mulPattern = regexp.MustCompile(`mul\(([1-9][0-9]*),([1-9][0-9]*)\)`) //nolint:gochecknoglobals // Meant as a constant
+
+// ReadInput finds the instructions in the corrupted memory, ignoring everything else. Only operands too large to be
+// multiplied are reported, as parsing.Errors.
+func ReadInput(scanner *bufio.Scanner) ([]Mul, error) {
+	var muls []Mul
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		for _, match := range mulPattern.FindAllStringSubmatchIndex(line, -1) {
+			var operands [2]int64
+			for iOperand := range operands {
+				start, end := match[2+2*iOperand], match[3+2*iOperand]
+				operand, err := strconv.ParseInt(line[start:end], 10, 64)
+				if err != nil {
+					return nil, parsing.Errorf(lineNum, start+1, line[start:end], "operand out of range")
+				}
+				operands[iOperand] = operand
+			}
+			muls = append(muls, Mul{X: operands[0], Y: operands[1]})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+
+	return muls, nil
+}
@@ -0,0 +1,48 @@
+package lib
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"testing"
+
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readMuls(input string) ([]Mul, error) {
+	return ReadInput(bufio.NewScanner(strings.NewReader(input)))
+}
+
+// printMuls writes the instructions back out, without any corruption.
+func printMuls(muls []Mul) string {
+	var builder strings.Builder
+	for _, mul := range muls {
+		fmt.Fprintf(&builder, "mul(%d,%d)", mul.X, mul.Y)
+	}
+	return builder.String()
+}
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("xmul(2,4)%&mul[3,7]!@^do_not_mul(5,5)+mul(32,64]then(mul(11,8)mul(8,5))\n")
+	f.Add("mul(0,1)mul(01,2)mul(1, 2)\nmul(3,4)\n")
+	f.Add("mul(99999999999999999999,1)\n")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, input string) {
+		muls, err := readMuls(input)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+		for _, mul := range muls {
+			require.Positive(t, mul.X)
+			require.Positive(t, mul.Y)
+		}
+
+		reread, err := readMuls(printMuls(muls))
+		require.NoError(t, err)
+		assert.Equal(t, muls, reread)
+	})
+}
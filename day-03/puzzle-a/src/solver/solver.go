@@ -0,0 +1,36 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"daythree/a/lib"
+)
+
+type Args struct {
+	command.Flags
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(_ context.Context, args Args, input io.Reader, out *report.Report) error {
+	muls, err := lib.ReadInput(bufio.NewScanner(out.CountInput(input)))
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+	out.Parsed("instructions", len(muls))
+
+	sum := int64(0)
+	for _, mul := range muls {
+		sum += mul.X * mul.Y
+	}
+
+	slog.Info("solved", "sum", sum)
+	out.Answer("sum", sum)
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package lib
+
+import (
+	"bufio"
+	"regexp"
+	"strconv"
+
+	"common/parsing"
+)
+
+// Op is the kind of an Instruction.
+type Op int
+
+const (
+	OpMul Op = iota
+	OpDo
+	OpDont
+)
+
+// Instruction is a `mul(X,Y)`, `do()` or `don't()` instruction found in the corrupted memory. X and Y are only set for
+// `mul`.
+type Instruction struct {
+	Op Op
+	X  int64
+	Y  int64
+}
+
+var instructionPattern = regexp.MustCompile( //nolint:gochecknoglobals // Meant as a constant
+	`(mul\(([1-9][0-9]*),([1-9][0-9]*)\))|(do\(\))|(don't\(\))`)
+
+// ReadInput finds the instructions in the corrupted memory, ignoring everything else. Only operands too large to be
+// multiplied are reported, as parsing.Errors.
+func ReadInput(scanner *bufio.Scanner) ([]Instruction, error) {
+	var instructions []Instruction
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		for _, match := range instructionPattern.FindAllStringSubmatchIndex(line, -1) {
+			switch {
+			case match[8] >= 0:
+				instructions = append(instructions, Instruction{Op: OpDo})
+			case match[10] >= 0:
+				instructions = append(instructions, Instruction{Op: OpDont})
+			default:
+				var operands [2]int64
+				for iOperand := range operands {
+					start, end := match[4+2*iOperand], match[5+2*iOperand]
+					operand, err := strconv.ParseInt(line[start:end], 10, 64)
+					if err != nil {
+						return nil, parsing.Errorf(lineNum, start+1, line[start:end], "operand out of range")
+					}
+					operands[iOperand] = operand
+				}
+				instructions = append(instructions, Instruction{Op: OpMul, X: operands[0], Y: operands[1]})
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+
+	return instructions, nil
+}
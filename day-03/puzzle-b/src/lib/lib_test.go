@@ -0,0 +1,57 @@
+package lib
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"testing"
+
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readInstructions(input string) ([]Instruction, error) {
+	return ReadInput(bufio.NewScanner(strings.NewReader(input)))
+}
+
+// printInstructions writes the instructions back out, without any corruption.
+func printInstructions(instructions []Instruction) string {
+	var builder strings.Builder
+	for _, instruction := range instructions {
+		switch instruction.Op {
+		case OpMul:
+			fmt.Fprintf(&builder, "mul(%d,%d)", instruction.X, instruction.Y)
+		case OpDo:
+			builder.WriteString("do()")
+		case OpDont:
+			builder.WriteString("don't()")
+		}
+	}
+	return builder.String()
+}
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("xmul(2,4)&mul[3,7]!^don't()_mul(5,5)+mul(32,64](mul(11,8)undo()?mul(8,5))\n")
+	f.Add("mul(0,1)mul(01,2)do(1)don't\nmul(3,4)do()\n")
+	f.Add("don't()mul(99999999999999999999,1)\n")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, input string) {
+		instructions, err := readInstructions(input)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+		for _, instruction := range instructions {
+			if instruction.Op == OpMul {
+				require.Positive(t, instruction.X)
+				require.Positive(t, instruction.Y)
+			}
+		}
+
+		reread, err := readInstructions(printInstructions(instructions))
+		require.NoError(t, err)
+		assert.Equal(t, instructions, reread)
+	})
+}
@@ -0,0 +1,46 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"daythree/b/lib"
+)
+
+type Args struct {
+	command.Flags
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(_ context.Context, args Args, input io.Reader, out *report.Report) error {
+	instructions, err := lib.ReadInput(bufio.NewScanner(out.CountInput(input)))
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+	out.Parsed("instructions", len(instructions))
+
+	doIsOn := true
+	runningSum := int64(0)
+	for _, instruction := range instructions {
+		switch instruction.Op {
+		case lib.OpMul:
+			if doIsOn {
+				runningSum += instruction.X * instruction.Y
+			}
+		case lib.OpDo:
+			doIsOn = true
+		case lib.OpDont:
+			doIsOn = false
+		}
+	}
+
+	slog.Info("solved", "sum", runningSum)
+	out.Answer("sum", runningSum)
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package lib
+
+import (
+	"bufio"
+
+	"common/parsing"
+)
+
+// ReadInput reads the word search, one row of letters per line, skipping blank lines. Rows must be as long as the first
+// one.
+func ReadInput(scanner *bufio.Scanner) ([][]rune, error) {
+	grid := make([][]rune, 0)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		row := make([]rune, 0)
+		for iCol, c := range line {
+			if len(grid) > 0 && len(row) == len(grid[0]) {
+				return nil, parsing.Errorf(lineNum, iCol+1, line[iCol:], "row is longer than the first row (%d letters)", len(grid[0]))
+			}
+			row = append(row, c)
+		}
+		if len(grid) > 0 && len(row) < len(grid[0]) {
+			return nil, parsing.Errorf(lineNum, len(line)+1, "", "row is shorter than the first row (%d letters)", len(grid[0]))
+		}
+		grid = append(grid, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+
+	return grid, nil
+}
@@ -0,0 +1,68 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"dayfour/a/lib"
+)
+
+var (
+	target     = []rune{'X', 'M', 'A', 'S'}                                                    //nolint:gochecknoglobals // Meant as a constant
+	directions = [][]int{{1, 1}, {1, 0}, {1, -1}, {0, 1}, {0, -1}, {-1, 1}, {-1, 0}, {-1, -1}} //nolint:gochecknoglobals // Meant as a constant
+)
+
+type Args struct {
+	command.Flags
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(_ context.Context, args Args, input io.Reader, out *report.Report) error {
+	array, err := lib.ReadInput(bufio.NewScanner(out.CountInput(input)))
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+	out.Parsed("rows", len(array))
+
+	nFound := doSearch(array)
+
+	slog.Info("solved", "found", nFound)
+	out.Answer("found", nFound)
+
+	return nil
+}
+
+func doSearch(array [][]rune) int {
+	nFound := 0
+	for i := range array {
+		for j := range array[i] {
+			for _, dir := range directions {
+				if searchInDir(array, i, j, dir) {
+					nFound++
+				}
+			}
+		}
+	}
+
+	return nFound
+}
+
+func searchInDir(array [][]rune, i, j int, dir []int) bool {
+	for step := range target {
+		x := i + dir[0]*step
+		y := j + dir[1]*step
+		if x < 0 || x >= len(array) || y < 0 || y >= len(array[x]) {
+			return false
+		}
+		if array[x][y] != target[step] {
+			return false
+		}
+	}
+
+	return true
+}
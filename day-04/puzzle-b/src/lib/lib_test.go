@@ -0,0 +1,51 @@
+package lib
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readGrid(input string) ([][]rune, error) {
+	return ReadInput(bufio.NewScanner(strings.NewReader(input)))
+}
+
+// printGrid writes the grid back out in the puzzle format.
+func printGrid(grid [][]rune) string {
+	var builder strings.Builder
+	for _, row := range grid {
+		builder.WriteString(string(row))
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("MMMSXXMASM\nMSAMXMSMSA\nAMXSXMAAMM\nMSAMASMSMX\nXMASAMXAMM\n")
+	f.Add("XMAS\nXMA\n")
+	f.Add("XMAS\n\nXMASS\n")
+	f.Add("é\nß\n")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, input string) {
+		grid, err := readGrid(input)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+		for _, row := range grid {
+			require.Len(t, row, len(grid[0]))
+		}
+
+		// A letter \r ending a row would be read back as part of a \r\n line ending.
+		if !strings.Contains(input, "\r") {
+			reread, err := readGrid(printGrid(grid))
+			require.NoError(t, err)
+			assert.Equal(t, grid, reread)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"dayfour/b/lib"
+)
+
+var (
+	target = []rune{'M', 'A', 'S'}                       //nolint:gochecknoglobals // Meant as a constant
+	diags  = [][]int{{1, 1}, {1, -1}, {-1, 1}, {-1, -1}} //nolint:gochecknoglobals // Meant as a constant
+)
+
+type Args struct {
+	command.Flags
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(_ context.Context, args Args, input io.Reader, out *report.Report) error {
+	array, err := lib.ReadInput(bufio.NewScanner(out.CountInput(input)))
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+	out.Parsed("rows", len(array))
+
+	nFound := doSearch(array)
+
+	slog.Info("solved", "found", nFound)
+	out.Answer("found", nFound)
+
+	return nil
+}
+
+func doSearch(array [][]rune) int {
+	nDiags := len(diags)
+	nFound := 0
+	for i := range array {
+		for j := range array[i] {
+			for dirIdx1, dir1 := range diags {
+				for dirIdx2 := dirIdx1 + 1; dirIdx2 < nDiags; dirIdx2++ {
+					dir2 := diags[dirIdx2]
+					if searchInTwoDirs(array, i, j, dir1, dir2) {
+						nFound++
+					}
+				}
+			}
+		}
+	}
+
+	return nFound
+}
+
+func searchInTwoDirs(array [][]rune, i, j int, dir1, dir2 []int) bool {
+	return searchInDir(array, i-dir1[0], j-dir1[1], dir1) &&
+		searchInDir(array, i-dir2[0], j-dir2[1], dir2)
+}
+
+func searchInDir(array [][]rune, i, j int, dir []int) bool {
+	for step, targetRune := range target {
+		x := i + dir[0]*step
+		y := j + dir[1]*step
+		if x < 0 || x >= len(array) || y < 0 || y >= len(array[x]) {
+			return false
+		}
+		if array[x][y] != targetRune {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,65 @@
+package lib
+
+import (
+	"bufio"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readManual(input string) (map[int][]int, [][]int, error) {
+	return ReadInput(bufio.NewScanner(strings.NewReader(input)))
+}
+
+// printManual writes the rules and the updates back out in the puzzle format.
+func printManual(precedenceMap map[int][]int, updates [][]int) string {
+	var builder strings.Builder
+	for _, before := range slices.Sorted(maps.Keys(precedenceMap)) {
+		for _, after := range precedenceMap[before] {
+			fmt.Fprintf(&builder, "%d|%d\n", before, after)
+		}
+	}
+	builder.WriteByte('\n')
+	for _, update := range updates {
+		pages := make([]string, len(update))
+		for iPage, page := range update {
+			pages[iPage] = strconv.Itoa(page)
+		}
+		builder.WriteString(strings.Join(pages, ","))
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("47|53\n97|13\n97|61\n75|29\n\n75,47,61,53,29\n97,61,53,29,13\n")
+	f.Add("47|53\n\n\n75,47\n  \n")
+	f.Add("47|53|61\n")
+	f.Add("47 53\n")
+	f.Add("47|53\n\n75;47\n")
+	f.Add("\n75,47\n")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, input string) {
+		precedenceMap, updates, err := readManual(input)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+		for _, update := range updates {
+			require.NotEmpty(t, update)
+		}
+
+		rereadMap, rereadUpdates, err := readManual(printManual(precedenceMap, updates))
+		require.NoError(t, err)
+		assert.Equal(t, precedenceMap, rereadMap)
+		assert.Equal(t, updates, rereadUpdates)
+	})
+}
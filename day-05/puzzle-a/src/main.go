@@ -1,99 +1,20 @@
 package main
 
 import (
-	"bufio"
-	"log"
-	"os"
-	"strconv"
-	"strings"
+	"embed"
 
-	"github.com/samber/lo"
+	"common/command"
+	"dayfive/a/solver"
 )
 
-func main() {
-	file, err := os.Open("../input/input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer func(file *os.File) {
-		err := file.Close()
-		if err != nil {
-			log.Fatal(err)
-		}
-	}(file)
-
-	scanner := bufio.NewScanner(file)
-
-	// Read in the precedence rules
-	precedenceMap := make(map[int][]int)
-	for scanner.Scan() {
-		line := scanner.Text()
-		fields := strings.Split(line, "|")
-		if len(fields) != 2 {
-			break
-		}
-
-		values := lo.Map(fields, func(item string, _ int) int {
-			num, err := strconv.Atoi(item)
-			if err != nil {
-				log.Panic(err)
-			}
-			return num
-		})
-
-		precedenceMap[values[0]] = append(precedenceMap[values[0]], values[1])
-	}
-
-	// Read in the page sets
-	runningTotal := 0
-	for scanner.Scan() {
-		line := scanner.Text()
-		fields := strings.Split(line, ",")
-		values := lo.Map(fields, func(item string, _ int) int {
-			num, err := strconv.Atoi(item)
-			if err != nil {
-				log.Panic(err)
-			}
-			return num
-		})
-
-		if !isValid(values, precedenceMap) {
-			continue
-		}
-
-		nValues := len(values)
-		if nValues%2 == 0 {
-			log.Printf("value list %v is not of odd length", values)
-			continue
-		}
+// day picks the cached input to read when no input file is given.
+const day = 5
 
-		middle := nValues / 2
-		runningTotal += values[middle]
-	}
+// exampleFS holds the examples of the puzzle statement, for --example.
+//
+//go:embed examples
+var exampleFS embed.FS
 
-	log.Println(runningTotal)
-}
-
-func isValid(values []int, precedenceMap map[int][]int) bool {
-	posByValue := genPosByValueMap(values)
-
-	// Validate obligatory followers of each value
-	nValues := len(values)
-	for idx := 1; idx < nValues; idx++ {
-		obligFollowers := precedenceMap[values[idx]]
-		for _, follower := range obligFollowers {
-			pos, doesItOccur := posByValue[follower]
-			if doesItOccur && pos < idx {
-				return false
-			}
-		}
-	}
-
-	return true
-}
-
-func genPosByValueMap(values []int) map[int]int {
-	return lo.FromEntries(lo.Map(values, func(value, pos int) lo.Entry[int, int] {
-		return lo.Entry[int, int]{Key: value, Value: pos}
-	}))
+func main() {
+	command.Main(day, exampleFS, solver.Solve)
 }
@@ -0,0 +1,76 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"dayfive/a/lib"
+
+	"github.com/samber/lo"
+)
+
+type Args struct {
+	command.Flags
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(_ context.Context, args Args, input io.Reader, out *report.Report) error {
+	scanner := bufio.NewScanner(out.CountInput(input))
+
+	precedenceMap, updates, err := lib.ReadInput(scanner)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+	out.Parsed("pages", len(precedenceMap))
+	out.Parsed("updates", len(updates))
+
+	runningTotal := 0
+	for _, values := range updates {
+		if !isValid(values, precedenceMap) {
+			continue
+		}
+
+		nValues := len(values)
+		if nValues%2 == 0 {
+			slog.Warn("value list is not of odd length", "values", values)
+			continue
+		}
+
+		middle := nValues / 2
+		runningTotal += values[middle]
+	}
+
+	slog.Info("solved", "middlePageSum", runningTotal)
+	out.Answer("middlePageSum", runningTotal)
+
+	return nil
+}
+
+func isValid(values []int, precedenceMap map[int][]int) bool {
+	posByValue := genPosByValueMap(values)
+
+	// Validate obligatory followers of each value
+	nValues := len(values)
+	for idx := 1; idx < nValues; idx++ {
+		obligFollowers := precedenceMap[values[idx]]
+		for _, follower := range obligFollowers {
+			pos, doesItOccur := posByValue[follower]
+			if doesItOccur && pos < idx {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func genPosByValueMap(values []int) map[int]int {
+	return lo.FromEntries(lo.Map(values, func(value, pos int) lo.Entry[int, int] {
+		return lo.Entry[int, int]{Key: value, Value: pos}
+	}))
+}
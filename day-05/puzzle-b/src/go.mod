@@ -1,7 +1,23 @@
-module main
+module dayfive/b
 
 go 1.23.4
 
-require github.com/samber/lo v1.47.0
+require (
+	github.com/samber/lo v1.47.0
+	github.com/stretchr/testify v1.10.0
+)
 
-require golang.org/x/text v0.16.0 // indirect
+require (
+	github.com/alexflint/go-arg v1.6.1 // indirect
+	github.com/alexflint/go-scalar v1.2.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+require (
+	common v0.0.0-00010101000000-000000000000
+	golang.org/x/text v0.16.0 // indirect
+)
+
+replace common => ../../../common
@@ -0,0 +1,67 @@
+package lib
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+
+	"common/parsing"
+)
+
+// ReadInput reads the page ordering rules, as `X|Y` lines meaning that page X must come before page Y, and after a blank
+// line the updates, as comma-separated page numbers.
+func ReadInput(scanner *bufio.Scanner) (map[int][]int, [][]int, error) {
+	precedenceMap := make(map[int][]int)
+	var updates [][]int
+	inRules := true
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if inRules && len(line) == 0 {
+			inRules = false
+			continue
+		}
+		if !inRules && len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		separator := ","
+		if inRules {
+			separator = "|"
+		}
+		values, err := parseNumbers(line, lineNum, separator)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if inRules {
+			if len(values) != 2 { //nolint:mnd // A rule relates two pages
+				return nil, nil, parsing.Errorf(lineNum, 1, line, "expected a rule of the form X|Y")
+			}
+			precedenceMap[values[0]] = append(precedenceMap[values[0]], values[1])
+			continue
+		}
+		updates = append(updates, values)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err //nolint:wrapcheck // Toy code
+	}
+
+	return precedenceMap, updates, nil
+}
+
+func parseNumbers(line string, lineNum int, separator string) ([]int, error) {
+	var values []int
+	col := 1
+	for _, item := range strings.Split(line, separator) {
+		num, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, parsing.Errorf(lineNum, col, item, "expected a page number")
+		}
+		values = append(values, num)
+		col += len(item) + len(separator)
+	}
+
+	return values, nil
+}
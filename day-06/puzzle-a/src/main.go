@@ -1,79 +1,20 @@
 package main
 
 import (
-	"bufio"
-	"log"
-	"os"
+	"embed"
 
-	"daysix/lib"
+	"common/command"
+	"daysix/a/solver"
 )
 
-func main() {
-	file, err := os.Open("../input/input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer func(file *os.File) {
-		err := file.Close()
-		if err != nil {
-			log.Fatal(err)
-		}
-	}(file)
-
-	scanner := bufio.NewScanner(file)
-
-	// Read in the array
-	array, initialCoords := lib.ReadArray(scanner)
-
-	dimensions := lib.Coord{Row: len(array), Col: len(array[0])}
-	if dimensions.Row < 1 {
-		log.Panic("no rows in input")
-	}
-
-	if !initialCoords.IsValid(dimensions) {
-		log.Panicf("initial coordinates %v are not valid (dimensions: %v)", initialCoords, dimensions)
-	}
-
-	log.Printf("finished reading array (%d rows)", dimensions.Row)
-	log.Printf("initial coordinates: %v", initialCoords)
+// day picks the cached input to read when no input file is given.
+const day = 6
 
-	// Do the walkabout
-	nVisited := walkabout(initialCoords, dimensions, array)
+// exampleFS holds the examples of the puzzle statement, for --example.
+//
+//go:embed examples
+var exampleFS embed.FS
 
-	log.Printf("visited %d cells", nVisited)
-}
-
-func walkabout(initialCoords, dimensions lib.Coord, array [][]lib.Cell) int {
-	initialDir := lib.Coord{Row: -1, Col: 0}
-	currentCoords := initialCoords
-	currentDir := initialDir
-	nVisited := 1
-	timesReset := 0
-	for {
-		if currentCoords == initialCoords && currentDir == initialDir {
-			timesReset++
-		}
-
-		if timesReset > 1 {
-			log.Panic("we're in a loop!") //nolint:revive // Toy code
-		}
-
-		nextCoords := currentCoords.MoveOne(currentDir)
-		if !nextCoords.IsValid(dimensions) {
-			break
-		}
-
-		switch array[nextCoords.Row][nextCoords.Col] {
-		case lib.Empty:
-			array[nextCoords.Row][nextCoords.Col] = lib.Visited
-			nVisited++
-			fallthrough
-		case lib.Visited:
-			currentCoords = nextCoords
-			continue
-		case lib.Blocked:
-			currentDir = lib.TurnRight(currentDir)
-		}
-	}
-	return nVisited
+func main() {
+	command.Main(day, exampleFS, solver.Solve)
 }
@@ -0,0 +1,87 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"daysix/a/lib"
+)
+
+type Args struct {
+	command.Flags
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	scanner := bufio.NewScanner(out.CountInput(input))
+
+	// Read in the array
+	array, initialCoords, err := lib.ReadArray(scanner)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	dimensions := lib.Coord{Row: len(array), Col: len(array[0])}
+
+	slog.Debug("finished reading array", "rows", dimensions.Row)
+	out.Parsed("rows", dimensions.Row)
+	out.Parsed("cols", dimensions.Col)
+	slog.Debug("initial coordinates", "coords", initialCoords)
+
+	// Do the walkabout
+	nVisited, err := walkabout(ctx, initialCoords, dimensions, array)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("solved", "visitedCells", nVisited)
+	out.Answer("visitedCells", nVisited)
+
+	return nil
+}
+
+// walkabout only notices the loops that pass the start again, so it also stops once ctx is cancelled.
+func walkabout(ctx context.Context, initialCoords, dimensions lib.Coord, array [][]lib.Cell) (int, error) {
+	initialDir := lib.Coord{Row: -1, Col: 0}
+	currentCoords := initialCoords
+	currentDir := initialDir
+	nVisited := 1
+	timesReset := 0
+	for {
+		if currentCoords == initialCoords && currentDir == initialDir {
+			timesReset++
+		}
+
+		if timesReset > 1 {
+			return 0, errors.New("the guard walks in a loop")
+		}
+
+		nextCoords := currentCoords.MoveOne(currentDir)
+		if !nextCoords.IsValid(dimensions) {
+			break
+		}
+
+		switch array[nextCoords.Row][nextCoords.Col] {
+		case lib.Empty:
+			array[nextCoords.Row][nextCoords.Col] = lib.Visited
+			nVisited++
+			fallthrough
+		case lib.Visited:
+			currentCoords = nextCoords
+			continue
+		case lib.Blocked:
+			err := ctx.Err()
+			if err != nil {
+				return 0, err //nolint:wrapcheck // Toy code
+			}
+			currentDir = lib.TurnRight(currentDir)
+		}
+	}
+	return nVisited, nil
+}
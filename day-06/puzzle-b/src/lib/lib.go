@@ -2,7 +2,9 @@ package lib
 
 import (
 	"bufio"
-	"log"
+	"strings"
+
+	"common/parsing"
 )
 
 type Coord struct {
@@ -47,18 +49,31 @@ func TurnRight(dir Coord) Coord {
 	}
 }
 
-func ReadArray(scanner *bufio.Scanner) ([][]Cell, Coord) {
+// ReadArray reads the lab map up to the first blank line, along with the guard's starting point. The map must have at
+// least one row, all of the same width, and exactly one starting point.
+func ReadArray(scanner *bufio.Scanner) ([][]Cell, Coord, error) {
 	array := make([][]Cell, 0)
 	initialCoords := Coord{Row: -1, Col: -1}
+	lineNum := 0
 	for scanner.Scan() {
+		lineNum++
 		line := scanner.Text()
+		if len(strings.TrimSpace(line)) < 1 {
+			break
+		}
+		if len(array) > 0 && len(line) != len(array[0]) {
+			width := len(array[0])
+			return nil, initialCoords, parsing.Errorf(lineNum, min(len(line), width)+1, "", "expected %d columns, got %d", width, len(line))
+		}
+
 		row := make([]Cell, 0)
-		for _, char := range line {
+		for iByte, char := range line {
 			currentCoords := Coord{Row: len(array), Col: len(row)}
 			switch char {
 			case '^':
 				if initialCoords != (Coord{Row: -1, Col: -1}) {
-					log.Panicf("multiple starting points found: had already encountered %v, and now encountered %v", initialCoords, currentCoords) //nolint:revive // Toy code
+					return nil, initialCoords, parsing.Errorf(lineNum, iByte+1, string(char),
+						"multiple starting points found: had already encountered %v", initialCoords)
 				}
 				initialCoords = currentCoords
 				row = append(row, Visited)
@@ -67,10 +82,16 @@ func ReadArray(scanner *bufio.Scanner) ([][]Cell, Coord) {
 			case '#':
 				row = append(row, Blocked)
 			default:
-				log.Panicf("unexpected character in input: %v (current coordinates: %v)", char, currentCoords) //nolint:revive // Toy code
+				return nil, initialCoords, parsing.Errorf(lineNum, iByte+1, string(char), "unexpected character in input")
 			}
 		}
 		array = append(array, row)
 	}
-	return array, initialCoords
+	if err := scanner.Err(); err != nil {
+		return nil, initialCoords, err //nolint:wrapcheck // Toy code
+	}
+	if initialCoords == (Coord{Row: -1, Col: -1}) {
+		return nil, initialCoords, parsing.Errorf(max(lineNum, 1), 1, "", "no starting point found")
+	}
+	return array, initialCoords, nil
 }
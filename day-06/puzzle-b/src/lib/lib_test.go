@@ -1,14 +1,99 @@
 package lib
 
 import (
+	"bufio"
+	"strings"
 	"testing"
 
+	"common/parsing"
+	"common/parsing/parsingtest"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+func readLab(input string) ([][]Cell, Coord, error) {
+	return ReadArray(bufio.NewScanner(strings.NewReader(input)))
+}
+
+// printLab writes the map back out in the puzzle format, the guard facing up from the starting point.
+func printLab(array [][]Cell) string {
+	cellRunes := map[Cell]rune{Empty: '.', Visited: '^', Blocked: '#'}
+	var builder strings.Builder
+	for _, row := range array {
+		for _, cell := range row {
+			builder.WriteRune(cellRunes[cell])
+		}
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
 func TestTurnRight(t *testing.T) {
 	assert.Equal(t, Coord{Row: 0, Col: -1}, TurnRight(Coord{Row: 1, Col: 0}))
 	assert.Equal(t, Coord{Row: 1, Col: 0}, TurnRight(Coord{Row: 0, Col: 1}))
 	assert.Equal(t, Coord{Row: 0, Col: 1}, TurnRight(Coord{Row: -1, Col: 0}))
 	assert.Equal(t, Coord{Row: -1, Col: 0}, TurnRight(Coord{Row: 0, Col: -1}))
 }
+
+func TestReadArrayErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		line  int
+		col   int
+		err   string
+	}{
+		{name: "empty", input: "", line: 1, col: 1, err: "no starting point found"},
+		{name: "no start", input: "..\n.#\n", line: 2, col: 1, err: "no starting point found"},
+		{name: "short row", input: "..\n^\n", line: 2, col: 2, err: "expected 2 columns, got 1"},
+		{name: "long row", input: "...\n.\n.^.\n", line: 2, col: 2, err: "expected 3 columns, got 1"},
+		{name: "two starts", input: ".^\n^.\n", line: 2, col: 1, err: "multiple starting points found: had already encountered {0 1}"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := readLab(test.input)
+			var parseErr *parsing.Error
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, test.line, parseErr.Line)
+			assert.Equal(t, test.col, parseErr.Col)
+			assert.EqualError(t, parseErr.Err, test.err)
+		})
+	}
+}
+
+func FuzzReadArray(f *testing.F) {
+	f.Add("....#.....\n.........#\n..........\n..#.......\n.......#..\n..........\n.#..^.....\n")
+	f.Add("..\n^\n")
+	f.Add("...\n.\n.^.\n")
+	f.Add(".^\n^.\n")
+	f.Add("..\n.>\n")
+	f.Add("..\n.#\n")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, input string) {
+		array, initialCoords, err := readLab(input)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+
+		// Every map read has rows of one width and a single starting point.
+		require.NotEmpty(t, array)
+		nVisited := 0
+		for _, row := range array {
+			require.Len(t, row, len(array[0]))
+			for _, cell := range row {
+				if cell == Visited {
+					nVisited++
+				}
+			}
+		}
+		require.Equal(t, 1, nVisited)
+		assert.Equal(t, Visited, array[initialCoords.Row][initialCoords.Col])
+
+		rereadArray, rereadCoords, err := readLab(printLab(array))
+		require.NoError(t, err)
+		assert.Equal(t, array, rereadArray)
+		assert.Equal(t, initialCoords, rereadCoords)
+	})
+}
@@ -0,0 +1,158 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"daysix/b/lib"
+
+	"github.com/hashicorp/go-set/v3"
+	"github.com/tiendc/go-deepcopy"
+)
+
+type Args struct {
+	command.Flags
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	scanner := bufio.NewScanner(out.CountInput(input))
+
+	// Read in the array
+	array, initialCoords, err := lib.ReadArray(scanner)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	dimensions := lib.Coord{Row: len(array), Col: len(array[0])}
+
+	slog.Debug("finished reading array", "rows", dimensions.Row)
+	out.Parsed("rows", dimensions.Row)
+	out.Parsed("cols", dimensions.Col)
+	slog.Debug("initial coordinates", "coords", initialCoords)
+
+	// Do an initial walkabout to determine which coordinates are visited *without*
+	// blocking any additional cells
+	err = walkabout(ctx, initialCoords, dimensions, array)
+	if err != nil {
+		return err
+	}
+
+	initialVisitationArray := make([][]lib.Cell, 0)
+	err = deepcopy.Copy(&initialVisitationArray, array)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	// Now, for each visited cell, check if blocking it would create a loop
+	nLoopifiers := 0
+	for row := range dimensions.Row {
+		err = ctx.Err()
+		if err != nil {
+			return err //nolint:wrapcheck // Toy code
+		}
+
+		for col := range dimensions.Col {
+			currentCoords := lib.Coord{Row: row, Col: col}
+			if currentCoords == initialCoords {
+				continue
+			}
+
+			if array[currentCoords.Row][currentCoords.Col] == lib.Blocked {
+				continue
+			}
+
+			if initialVisitationArray[currentCoords.Row][currentCoords.Col] != lib.Visited {
+				continue
+			}
+
+			array[currentCoords.Row][currentCoords.Col] = lib.Blocked
+			if isLoopful(initialCoords, dimensions, array) {
+				nLoopifiers++
+			}
+			array[currentCoords.Row][currentCoords.Col] = lib.Empty
+		}
+	}
+
+	slog.Info("solved", "loopifiers", nLoopifiers)
+	out.Answer("loopifiers", nLoopifiers)
+
+	return nil
+}
+
+// walkabout only notices the loops that pass the start again, so it also stops once ctx is cancelled.
+func walkabout(ctx context.Context, initialCoords, dimensions lib.Coord, array [][]lib.Cell) error {
+	initialDir := lib.Coord{Row: -1, Col: 0}
+	currentCoords := initialCoords
+	currentDir := initialDir
+	nVisited := 1
+	timesReset := 0
+	for {
+		if currentCoords == initialCoords && currentDir == initialDir {
+			timesReset++
+		}
+
+		if timesReset > 1 {
+			return errors.New("the guard walks in a loop")
+		}
+
+		nextCoords := currentCoords.MoveOne(currentDir)
+		if !nextCoords.IsValid(dimensions) {
+			break
+		}
+
+		switch array[nextCoords.Row][nextCoords.Col] {
+		case lib.Empty:
+			array[nextCoords.Row][nextCoords.Col] = lib.Visited
+			nVisited++
+			fallthrough
+		case lib.Visited:
+			currentCoords = nextCoords
+			continue
+		case lib.Blocked:
+			err := ctx.Err()
+			if err != nil {
+				return err //nolint:wrapcheck // Toy code
+			}
+			currentDir = lib.TurnRight(currentDir)
+		}
+	}
+
+	return nil
+}
+
+func isLoopful(initialCoords, dimensions lib.Coord, array [][]lib.Cell) bool {
+	initialDir := lib.Coord{Row: -1, Col: 0}
+	current := lib.Visitation{
+		Loc: initialCoords,
+		Dir: initialDir,
+	}
+	turns := set.New[lib.Visitation](0)
+	for {
+		nextCoords := current.Loc.MoveOne(current.Dir)
+		if !nextCoords.IsValid(dimensions) {
+			return false
+		}
+
+		switch array[nextCoords.Row][nextCoords.Col] {
+		case lib.Empty:
+			fallthrough
+		case lib.Visited:
+			current.Loc = nextCoords
+			continue
+		case lib.Blocked:
+			if turns.Contains(current) {
+				return true
+			}
+			turns.Insert(current)
+			current.Dir = lib.TurnRight(current.Dir)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log"
+	"log/slog"
+	"math"
+
+	"common/command"
+	"common/report"
+	"dayseven/a/lib"
+)
+
+type Operator int
+
+const (
+	OpAdd Operator = iota
+	OpMul
+	NumOfDiffOperators
+)
+
+// cancelCheckInterval is the number of operator combinations tried between checks for cancellation, as an equation
+// with many operands has too many combinations to run to the end once its solve is cancelled.
+const cancelCheckInterval = 1 << 16
+
+type Args struct {
+	command.Flags
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	equations, err := lib.ReadInput(bufio.NewScanner(out.CountInput(input)))
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+	out.Parsed("equations", len(equations))
+
+	maxAttainable := int64(0)
+	runningTotal := int64(0)
+	for _, equation := range equations {
+		maxAttainable += equation.Result
+		solvable, err := isSolvable(ctx, equation.Result, equation.Operands)
+		if err != nil {
+			return err
+		}
+		if solvable {
+			runningTotal += equation.Result
+		}
+	}
+
+	slog.Info("solved", "maxAttainable", maxAttainable, "runningTotal", runningTotal)
+	out.Answer("maxAttainable", maxAttainable)
+	out.Answer("runningTotal", runningTotal)
+
+	return nil
+}
+
+func isSolvable(ctx context.Context, result int64, operands []int64) (bool, error) {
+	nOperands := len(operands)
+	nOps := nOperands - 1
+	ops := make([]Operator, nOps)
+	nCombinations := math.Pow(float64(NumOfDiffOperators), float64(nOps))
+	for iCombo := range int64(nCombinations) {
+		if iCombo%cancelCheckInterval == 0 {
+			err := ctx.Err()
+			if err != nil {
+				return false, err //nolint:wrapcheck // Toy code
+			}
+		}
+
+		combo := iCombo
+		for iOp := range nOps {
+			ops[iOp] = Operator(combo % int64(NumOfDiffOperators))
+			combo /= int64(NumOfDiffOperators)
+		}
+
+		if result == calc(operands, ops) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func calc(operands []int64, ops []Operator) int64 {
+	nOperands := len(operands)
+	if nOperands < 1 {
+		return 0
+	}
+
+	result := operands[0]
+	for idx := 1; idx < nOperands; idx++ {
+		switch ops[idx-1] {
+		case OpAdd:
+			result += operands[idx]
+		case OpMul:
+			result *= operands[idx]
+		case NumOfDiffOperators:
+			log.Panicf("internal error: unknown operator %d", ops[idx-1]) //nolint:revive // Toy code
+		}
+	}
+
+	return result
+}
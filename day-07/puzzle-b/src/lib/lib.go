@@ -0,0 +1,103 @@
+package lib
+
+import (
+	"bufio"
+	"errors"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"common/parsing"
+)
+
+// Equation is a calibration equation, `Result: Operands...`, whose operators are missing.
+type Equation struct {
+	Result   big.Int
+	Operands []big.Int
+}
+
+// ReadInput reads one equation per line, skipping blank lines. The result and the operands are positive numbers without
+// leading zeros.
+func ReadInput(scanner *bufio.Scanner) ([]Equation, error) {
+	var equations []Equation
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		fields, cols := fieldColumns(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		resultText, ok := strings.CutSuffix(fields[0], ":")
+		if !ok {
+			return nil, parsing.Errorf(lineNum, cols[0], fields[0], "expected the result followed by `:`")
+		}
+		result, err := parseNumber(resultText)
+		if err != nil {
+			return nil, parsing.Errorf(lineNum, cols[0], resultText, "invalid result: %w", err)
+		}
+		if len(fields) == 1 {
+			return nil, parsing.Errorf(lineNum, len(line)+1, "", "expected at least one operand")
+		}
+
+		operands := make([]big.Int, len(fields)-1)
+		for iField, field := range fields[1:] {
+			operands[iField], err = parseNumber(field)
+			if err != nil {
+				return nil, parsing.Errorf(lineNum, cols[iField+1], field, "invalid operand: %w", err)
+			}
+		}
+		equations = append(equations, Equation{Result: result, Operands: operands})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+
+	return equations, nil
+}
+
+func parseNumber(text string) (big.Int, error) {
+	var num big.Int
+	if !isNumber(text) {
+		return num, errNotANumber
+	}
+	num.SetString(text, 10) //nolint:mnd // false positive
+
+	return num, nil
+}
+
+var errNotANumber = errors.New("expected a positive number without leading zeros")
+
+// isNumber tells whether the text is made of digits, the first one not being 0.
+func isNumber(text string) bool {
+	if text == "" || text[0] == '0' {
+		return false
+	}
+	for _, c := range text {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fieldColumns splits the line around runs of white space, like strings.Fields, also returning the 1-based column at
+// which every field starts.
+func fieldColumns(line string) ([]string, []int) {
+	var fields []string
+	var cols []int
+	start := -1
+	for iByte, char := range line + " " {
+		switch {
+		case unicode.IsSpace(char) && start >= 0:
+			fields = append(fields, line[start:iByte])
+			cols = append(cols, start+1)
+			start = -1
+		case !unicode.IsSpace(char) && start < 0:
+			start = iByte
+		}
+	}
+	return fields, cols
+}
@@ -0,0 +1,57 @@
+package lib
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"testing"
+
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readEquations(input string) ([]Equation, error) {
+	return ReadInput(bufio.NewScanner(strings.NewReader(input)))
+}
+
+// printEquations writes the equations back out in the puzzle format.
+func printEquations(equations []Equation) string {
+	var builder strings.Builder
+	for _, equation := range equations {
+		fmt.Fprintf(&builder, "%v:", &equation.Result)
+		for _, operand := range equation.Operands {
+			fmt.Fprintf(&builder, " %v", &operand)
+		}
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("190: 10 19\n3267: 81 40 27\n83: 17 5\n156: 15 6\n7290: 6 8 6 15\n")
+	f.Add("\n  3:\t1  2 \n\n")
+	f.Add("3 1 2\n")
+	f.Add("3:\n")
+	f.Add("03: 1 2\n")
+	f.Add("3: 1 -2\n")
+	f.Add("99999999999999999999: 1\n")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, input string) {
+		equations, err := readEquations(input)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+		for _, equation := range equations {
+			require.NotEmpty(t, equation.Operands)
+		}
+
+		// Equal big.Ints may differ in their internal capacity, so they are compared printed.
+		printed := printEquations(equations)
+		reread, err := readEquations(printed)
+		require.NoError(t, err)
+		assert.Equal(t, printed, printEquations(reread))
+	})
+}
@@ -1,48 +1,20 @@
 package main
 
 import (
-	"bufio"
-	"log"
-	"os"
+	"embed"
 
-	"main/lib"
-
-	"github.com/hashicorp/go-set/v3"
+	"common/command"
+	"dayeight/a/solver"
 )
 
-func main() {
-	file, err := os.Open("../input/input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer func(file *os.File) {
-		closeErr := file.Close()
-		if closeErr != nil {
-			log.Fatal(closeErr)
-		}
-	}(file)
-
-	scanner := bufio.NewScanner(file)
+// day picks the cached input to read when no input file is given.
+const day = 8
 
-	// Read in the array
-	dimensions, antennae := lib.ReadArray(scanner)
+// exampleFS holds the examples of the puzzle statement, for --example.
+//
+//go:embed examples
+var exampleFS embed.FS
 
-	// Find the antinodes
-	antinodes := set.New[lib.Coord](0)
-	for _, locs := range antennae {
-		for _, loc1 := range locs {
-			for _, loc2 := range locs {
-				if loc2 == loc1 {
-					continue
-				}
-				diff := loc2.Subtract(loc1)
-				projection := loc2.Add(diff)
-				if projection.IsValid(dimensions) {
-					antinodes.Insert(projection)
-				}
-			}
-		}
-	}
-
-	log.Printf("number of antinodes found: %d", antinodes.Size())
+func main() {
+	command.Main(day, exampleFS, solver.Solve)
 }
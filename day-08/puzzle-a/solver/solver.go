@@ -0,0 +1,54 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"dayeight/a/lib"
+
+	"github.com/hashicorp/go-set/v3"
+)
+
+type Args struct {
+	command.Flags
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(_ context.Context, args Args, input io.Reader, out *report.Report) error {
+	scanner := bufio.NewScanner(out.CountInput(input))
+
+	// Read in the array
+	dimensions, antennae, err := lib.ReadArray(scanner)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	// Find the antinodes
+	antinodes := set.New[lib.Coord](0)
+	for _, locs := range antennae {
+		for _, loc1 := range locs {
+			for _, loc2 := range locs {
+				if loc2 == loc1 {
+					continue
+				}
+				diff := loc2.Subtract(loc1)
+				projection := loc2.Add(diff)
+				if projection.IsValid(dimensions) {
+					antinodes.Insert(projection)
+				}
+			}
+		}
+	}
+
+	slog.Info("solved", "antinodes", antinodes.Size())
+	out.Parsed("rows", dimensions.Row)
+	out.Parsed("cols", dimensions.Col)
+	out.Answer("antinodes", antinodes.Size())
+
+	return nil
+}
@@ -2,6 +2,15 @@ package lib
 
 import (
 	"bufio"
+	"context"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"common/parsing"
 )
 
 type Coord struct {
@@ -33,7 +42,7 @@ func (c Coord) Subtract(other Coord) Coord {
 	}
 }
 
-func ReadArray(scanner *bufio.Scanner) (Coord, map[rune][]Coord) {
+func ReadArray(scanner *bufio.Scanner) (Coord, map[rune][]Coord, error) {
 	nRows := 0
 	nCols := -1
 	antennae := make(map[rune][]Coord)
@@ -42,6 +51,9 @@ func ReadArray(scanner *bufio.Scanner) (Coord, map[rune][]Coord) {
 		if nCols < 0 {
 			nCols = len(line)
 		}
+		if len(line) != nCols {
+			return Coord{}, nil, parsing.Errorf(nRows+1, min(len(line), nCols)+1, "", "expected %d columns, got %d", nCols, len(line))
+		}
 		for iCol, char := range line {
 			if char == '.' {
 				continue
@@ -51,6 +63,280 @@ func ReadArray(scanner *bufio.Scanner) (Coord, map[rune][]Coord) {
 		}
 		nRows++
 	}
+	if err := scanner.Err(); err != nil {
+		return Coord{}, nil, err //nolint:wrapcheck // Toy code
+	}
+
+	return Coord{nRows, nCols}, antennae, nil
+}
+
+func (c Coord) Scale(factor int) Coord {
+	return Coord{
+		Row: c.Row * factor,
+		Col: c.Col * factor,
+	}
+}
+
+// Reduce divides both components by their greatest common divisor, yielding the smallest lattice step along the same
+// line.
+func (c Coord) Reduce() Coord {
+	divisor := gcd(c.Row, c.Col)
+	if divisor == 0 {
+		return c
+	}
+	return Coord{
+		Row: c.Row / divisor,
+		Col: c.Col / divisor,
+	}
+}
+
+func gcd(a, b int) int {
+	if a < 0 {
+		a = -a
+	}
+	if b < 0 {
+		b = -b
+	}
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// ReadSparse reads antennae given as a coordinate list rather than a dense grid. The list starts with a
+// "size ROWS COLS" line, followed by one "FREQ ROW COL" line per antenna. Blank lines and lines starting with '#' are
+// ignored.
+func ReadSparse(scanner *bufio.Scanner) (Coord, map[rune][]Coord, error) {
+	dimensions := Coord{Row: -1, Col: -1}
+	antennae := make(map[rune][]Coord)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if trimmed := strings.TrimSpace(line); trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		fields, cols := fieldColumns(line)
+		if len(fields) != 3 { //nolint:mnd // Name plus two coordinates
+			return Coord{}, nil, parsing.Errorf(lineNum, cols[0], line, "expected 3 fields, got %d", len(fields))
+		}
+		row, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return Coord{}, nil, parsing.Errorf(lineNum, cols[1], fields[1], "invalid row")
+		}
+		col, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return Coord{}, nil, parsing.Errorf(lineNum, cols[2], fields[2], "invalid column")
+		}
+		coord := Coord{Row: row, Col: col}
+
+		if fields[0] == "size" {
+			if dimensions.Row >= 0 {
+				return Coord{}, nil, parsing.Errorf(lineNum, cols[0], fields[0], "duplicate size line")
+			}
+			if row <= 0 || col <= 0 {
+				return Coord{}, nil, parsing.Errorf(lineNum, cols[1], line[cols[1]-1:], "invalid size %v", coord)
+			}
+			dimensions = coord
+			continue
+		}
+		if dimensions.Row < 0 {
+			return Coord{}, nil, parsing.Errorf(lineNum, cols[0], line, "antenna before the size line")
+		}
+
+		freq := []rune(fields[0])
+		if len(freq) != 1 {
+			return Coord{}, nil, parsing.Errorf(lineNum, cols[0], fields[0], "frequency must be a single character")
+		}
+		if freq[0] == '.' {
+			return Coord{}, nil, parsing.Errorf(lineNum, cols[0], fields[0], "frequency must not be `.`, which marks empty spots")
+		}
+		if !coord.IsValid(dimensions) {
+			return Coord{}, nil, parsing.Errorf(lineNum, cols[1], line[cols[1]-1:], "antenna is outside %v", dimensions)
+		}
+		antennae[freq[0]] = append(antennae[freq[0]], coord)
+	}
+	if err := scanner.Err(); err != nil {
+		return Coord{}, nil, err //nolint:wrapcheck // Toy code
+	}
+
+	if dimensions.Row < 0 {
+		return Coord{}, nil, parsing.Errorf(max(lineNum, 1), 1, "", "missing size line")
+	}
+
+	return dimensions, antennae, nil
+}
+
+// fieldColumns splits the line around runs of white space, like strings.Fields, also returning the 1-based column at
+// which every field starts.
+func fieldColumns(line string) ([]string, []int) {
+	var fields []string
+	var cols []int
+	start := -1
+	for iByte, char := range line + " " {
+		switch {
+		case unicode.IsSpace(char) && start >= 0:
+			fields = append(fields, line[start:iByte])
+			cols = append(cols, start+1)
+			start = -1
+		case !unicode.IsSpace(char) && start < 0:
+			start = iByte
+		}
+	}
+	return fields, cols
+}
+
+// ResonanceRules describes where a pair of same-frequency antennae produces antinodes.
+type ResonanceRules struct {
+	// Ratios lists the distance ratios (at least 2) at which a point that is collinear with both antennae is an antinode.
+	// Ignored when Harmonics is set.
+	Ratios []int
+	// Internal also accepts ratio points lying between the two antennae.
+	Internal bool
+	// Harmonics makes every lattice point on the line through the pair an antinode, stepping by the pair's difference.
+	Harmonics bool
+	// MaxSteps limits harmonics to this many steps beyond either antenna; zero means unbounded.
+	MaxSteps int
+	// ReduceByGCD steps harmonics by the difference divided by its GCD so that lattice points in between the antennae are
+	// caught too.
+	ReduceByGCD bool
+	// Forward only projects past the later antenna of each pair (in input order) instead of in both directions.
+	Forward bool
+}
+
+// Pair identifies the two antennae that produced an antinode.
+type Pair struct {
+	Frequency rune
+	First     Coord
+	Second    Coord
+}
+
+// FindAntinodes returns every antinode within the dimensions, along with the antenna pairs that produced it.
+// Frequencies are visited in rune order and antennae in input order, so the pair lists are deterministic. It stops once
+// ctx is cancelled, as a sparse map may have more pairs than can be visited in time.
+func FindAntinodes(
+	ctx context.Context, dimensions Coord, antennae map[rune][]Coord, rules ResonanceRules,
+) (map[Coord][]Pair, error) {
+	antinodes := make(map[Coord][]Pair)
+	freqs := slices.Sorted(maps.Keys(antennae))
+	for _, freq := range freqs {
+		locs := antennae[freq]
+		for iFirst, first := range locs {
+			err := ctx.Err()
+			if err != nil {
+				return nil, err //nolint:wrapcheck // Toy code
+			}
+			for _, second := range locs[iFirst+1:] {
+				if first == second {
+					continue
+				}
+				pair := Pair{Frequency: freq, First: first, Second: second}
+				var points []Coord
+				if rules.Harmonics {
+					points = harmonicPoints(dimensions, first, second, rules)
+				} else {
+					points = ratioPoints(dimensions, first, second, rules)
+				}
+				for _, point := range points {
+					antinodes[point] = append(antinodes[point], pair)
+				}
+			}
+		}
+	}
+
+	return antinodes, nil
+}
+
+func ratioPoints(dimensions Coord, first Coord, second Coord, rules ResonanceRules) []Coord {
+	diff := second.Subtract(first)
+	var points []Coord
+	add := func(point Coord) {
+		if point.IsValid(dimensions) && !slices.Contains(points, point) {
+			points = append(points, point)
+		}
+	}
+	for _, ratio := range rules.Ratios {
+		if ratio < 2 { //nolint:mnd // A ratio of one has no collinear solution outside the pair
+			continue
+		}
+
+		// Beyond the second antenna, the first is ratio times as far away.
+		if outside, ok := divide(diff, ratio-1); ok {
+			add(second.Add(outside))
+			if !rules.Forward {
+				add(first.Subtract(outside))
+			}
+		}
+
+		if !rules.Internal {
+			continue
+		}
+		// In between, one antenna is ratio times as far away as the other.
+		if inside, ok := divide(diff, ratio+1); ok {
+			add(first.Add(inside.Scale(ratio)))
+			add(second.Subtract(inside.Scale(ratio)))
+		}
+	}
+
+	return points
+}
+
+func divide(c Coord, divisor int) (Coord, bool) {
+	if c.Row%divisor != 0 || c.Col%divisor != 0 {
+		return Coord{}, false
+	}
+	return Coord{Row: c.Row / divisor, Col: c.Col / divisor}, true
+}
+
+func harmonicPoints(dimensions Coord, first Coord, second Coord, rules ResonanceRules) []Coord {
+	diff := second.Subtract(first)
+	step := diff
+	if rules.ReduceByGCD {
+		step = diff.Reduce()
+	}
+	// The second antenna sits this many steps past the first one.
+	span := 1
+	if step.Row != 0 {
+		span = diff.Row / step.Row
+	} else if step.Col != 0 {
+		span = diff.Col / step.Col
+	}
+
+	var points []Coord
+	add := func(steps int) bool {
+		point := first.Add(step.Scale(steps))
+		if !point.IsValid(dimensions) {
+			return false
+		}
+		points = append(points, point)
+		return true
+	}
+
+	// Walk from the first antenna past the second one, skipping over the middle of the pair when only harmonics close to
+	// an antenna are wanted.
+	last := math.MaxInt
+	if rules.MaxSteps > 0 {
+		last = span + rules.MaxSteps
+	}
+	for steps := 0; steps <= last; steps++ {
+		if rules.MaxSteps > 0 && steps > rules.MaxSteps && steps < span-rules.MaxSteps {
+			steps = span - rules.MaxSteps
+		}
+		if !add(steps) {
+			break
+		}
+	}
+	if rules.Forward {
+		return points
+	}
+
+	// Walk back from the first antenna.
+	for steps := -1; rules.MaxSteps == 0 || -steps <= rules.MaxSteps; steps-- {
+		if !add(steps) {
+			break
+		}
+	}
 
-	return Coord{nRows, nCols}, antennae
+	return points
 }
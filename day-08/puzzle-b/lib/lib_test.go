@@ -0,0 +1,117 @@
+package lib
+
+import (
+	"bufio"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readArray(input string) (Coord, map[rune][]Coord, error) {
+	return ReadArray(bufio.NewScanner(strings.NewReader(input)))
+}
+
+func readSparse(input string) (Coord, map[rune][]Coord, error) {
+	return ReadSparse(bufio.NewScanner(strings.NewReader(input)))
+}
+
+// printArray writes the antennae back out as a dense grid, every antenna at the byte offset it was read from.
+func printArray(dimensions Coord, antennae map[rune][]Coord) string {
+	rows := make([][]byte, dimensions.Row)
+	for iRow := range rows {
+		rows[iRow] = []byte(strings.Repeat(".", dimensions.Col))
+	}
+	for freq, coords := range antennae {
+		for _, coord := range coords {
+			utf8.EncodeRune(rows[coord.Row][coord.Col:], freq)
+		}
+	}
+	var builder strings.Builder
+	for _, row := range rows {
+		builder.Write(row)
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
+// printSparse writes the antennae back out as a coordinate list.
+func printSparse(dimensions Coord, antennae map[rune][]Coord) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "size %d %d\n", dimensions.Row, dimensions.Col)
+	for _, freq := range slices.Sorted(maps.Keys(antennae)) {
+		for _, coord := range antennae[freq] {
+			fmt.Fprintf(&builder, "%c %d %d\n", freq, coord.Row, coord.Col)
+		}
+	}
+	return builder.String()
+}
+
+// checkAntennae asserts that the antennae lie within the map.
+func checkAntennae(t *testing.T, dimensions Coord, antennae map[rune][]Coord) {
+	t.Helper()
+	for freq, coords := range antennae {
+		assert.NotEqual(t, '.', freq)
+		for _, coord := range coords {
+			assert.True(t, coord.IsValid(dimensions), "%c at %v outside %v", freq, coord, dimensions)
+		}
+	}
+}
+
+func FuzzReadArray(f *testing.F) {
+	f.Add("............\n........0...\n.....0......\n.......0....\n....0.......\n......A.....\n" +
+		"............\n............\n........A...\n.........A..\n............\n............\n")
+	f.Add("..\n.\n")
+	f.Add("é.\n.ß\n")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, input string) {
+		dimensions, antennae, err := readArray(input)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+		checkAntennae(t, dimensions, antennae)
+
+		// Invalid UTF-8 is read as replacement characters, and an antenna \r ending a row as part of a \r\n line ending.
+		if utf8.ValidString(input) && !strings.Contains(input, "\r") {
+			rereadDimensions, rereadAntennae, err := readArray(printArray(dimensions, antennae))
+			require.NoError(t, err)
+			assert.Equal(t, dimensions, rereadDimensions)
+			assert.Equal(t, antennae, rereadAntennae)
+		}
+	})
+}
+
+func FuzzReadSparse(f *testing.F) {
+	f.Add("# The example\nsize 12 12\n0 1 8\n0 2 5\n0 3 7\n0 4 4\nA 5 6\nA 8 8\nA 9 9\n")
+	f.Add("\n  size 2\t3\n\né 1 2\n")
+	f.Add("0 1 1\nsize 2 2\n")
+	f.Add("size 2 2\nsize 2 2\n")
+	f.Add("size 0 2\n")
+	f.Add("size 2 2\n0 2 0\n")
+	f.Add("size 2 2\n00 1 1\n")
+	f.Add("size 2 2\n. 1 1\n")
+	f.Add("size 2 2\n0 1\n")
+	f.Add("size 2 x\n")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, input string) {
+		dimensions, antennae, err := readSparse(input)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+		checkAntennae(t, dimensions, antennae)
+
+		rereadDimensions, rereadAntennae, err := readSparse(printSparse(dimensions, antennae))
+		require.NoError(t, err)
+		assert.Equal(t, dimensions, rereadDimensions)
+		assert.Equal(t, antennae, rereadAntennae)
+	})
+}
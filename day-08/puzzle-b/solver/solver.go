@@ -0,0 +1,292 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"log/slog"
+	"maps"
+	"math"
+	"os"
+	"slices"
+	"strings"
+
+	"common/command"
+	"common/report"
+	"dayeight/b/lib"
+)
+
+type Args struct {
+	command.Flags
+
+	Sparse      bool   `arg:"--sparse"        help:"input is a coordinate list (\"size ROWS COLS\", then \"FREQ ROW COL\" lines)"`
+	Ratios      []int  `arg:"--ratio,separate" help:"antinodes where one antenna is this many times as far as the other (repeatable; disables harmonics)"`
+	Internal    bool   `arg:"--internal"      help:"also accept ratio antinodes between the two antennae"`
+	MaxSteps    int    `arg:"--max-steps"     default:"0"    help:"only accept harmonics within this many steps of an antenna (0: unbounded)"`
+	NoReduceGCD bool   `arg:"--no-reduce-gcd" help:"step harmonics by the whole pair difference, skipping the lattice points in between"`
+	Forward     bool   `arg:"--forward"       help:"only project past the later antenna of each pair"`
+	Render      string `arg:"--render"        help:"write the map to stdout, as text or color"`
+	PNGFile     string `arg:"--png"           help:"write the map as a PNG image to this file"`
+	Pairs       bool   `arg:"--pairs"         help:"list the antenna pairs producing each antinode"`
+}
+
+const (
+	emptyMark         = '.'
+	antinodeMark      = '#'
+	sharedMark        = '%' // Antinode produced by several frequencies
+	overlapMark       = '*' // Antinode on top of an antenna
+	pngCellSize       = 8
+	maxRenderedCells  = 4_000_000
+	ansiReset         = "\x1b[0m"
+	ansiReverse       = "\x1b[7m"
+	ansiForegroundRGB = "\x1b[38;2;%d;%d;%dm"
+)
+
+var (
+	backgroundColor = color.RGBA{R: 24, G: 24, B: 24, A: 255}    //nolint:gochecknoglobals // Meant as a constant
+	sharedColor     = color.RGBA{R: 255, G: 255, B: 255, A: 255} //nolint:gochecknoglobals // Meant as a constant
+)
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	if out.IsJSON() && args.Render != "" {
+		return errors.New("--render writes to stdout and cannot be combined with --output json")
+	}
+
+	dimensions, antennae, err := readInput(args, input, out)
+	if err != nil {
+		return err
+	}
+
+	rules := lib.ResonanceRules{
+		Ratios:      args.Ratios,
+		Internal:    args.Internal,
+		Harmonics:   len(args.Ratios) == 0,
+		MaxSteps:    args.MaxSteps,
+		ReduceByGCD: !args.NoReduceGCD,
+		Forward:     args.Forward,
+	}
+	antinodes, err := lib.FindAntinodes(ctx, dimensions, antennae, rules)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	slog.Info("solved", "antinodes", len(antinodes))
+	out.Parsed("rows", dimensions.Row)
+	out.Parsed("cols", dimensions.Col)
+	out.Answer("antinodes", len(antinodes))
+
+	if args.Pairs {
+		logPairs(antinodes)
+	}
+	if args.Render == "" && args.PNGFile == "" {
+		return nil
+	}
+	// Sparse maps may be large enough for the number of cells to overflow, so compare one dimension at a time.
+	if dimensions.Col > 0 && dimensions.Row > maxRenderedCells/dimensions.Col {
+		return fmt.Errorf("map of %v is too large to render", dimensions)
+	}
+	palette := frequencyPalette(antennae)
+	switch args.Render {
+	case "":
+	case "text":
+		err := renderText(os.Stdout, dimensions, antennae, antinodes, nil)
+		if err != nil {
+			return err
+		}
+	case "color":
+		err := renderText(os.Stdout, dimensions, antennae, antinodes, palette)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown render mode %q", args.Render)
+	}
+	if args.PNGFile != "" {
+		return writePNGFile(args.PNGFile, dimensions, antennae, antinodes, palette)
+	}
+
+	return nil
+}
+
+// logPairs lists, in reading order, the antenna pairs producing each antinode.
+func logPairs(antinodes map[lib.Coord][]lib.Pair) {
+	coords := slices.SortedFunc(maps.Keys(antinodes), func(a, b lib.Coord) int {
+		return cmp.Or(cmp.Compare(a.Row, b.Row), cmp.Compare(a.Col, b.Col))
+	})
+	for _, coord := range coords {
+		pairs := make([]string, 0, len(antinodes[coord]))
+		for _, pair := range antinodes[coord] {
+			pairs = append(pairs, fmt.Sprintf("%c %v-%v", pair.Frequency, pair.First, pair.Second))
+		}
+		slog.Info("antinode", "coord", coord, "pairs", strings.Join(pairs, ", "))
+	}
+}
+
+// frequencyPalette gives every frequency its own colour, spread evenly around the colour wheel.
+func frequencyPalette(antennae map[rune][]lib.Coord) map[rune]color.RGBA {
+	freqs := slices.Sorted(maps.Keys(antennae))
+	palette := make(map[rune]color.RGBA, len(freqs))
+	for iFreq, freq := range freqs {
+		palette[freq] = hslColor(float64(iFreq)/float64(len(freqs)), 0.75, 0.6) //nolint:mnd // Bright on dark
+	}
+	return palette
+}
+
+func hslColor(hue, saturation, lightness float64) color.RGBA {
+	chroma := (1 - math.Abs(2*lightness-1)) * saturation
+	sector := hue * 6 //nolint:mnd // Six sectors of the colour wheel
+	x := chroma * (1 - math.Abs(math.Mod(sector, 2)-1))
+	var r, g, b float64
+	switch int(sector) {
+	case 0:
+		r, g = chroma, x
+	case 1:
+		r, g = x, chroma
+	case 2: //nolint:mnd // Sector index
+		g, b = chroma, x
+	case 3: //nolint:mnd // Sector index
+		g, b = x, chroma
+	case 4: //nolint:mnd // Sector index
+		r, b = x, chroma
+	default:
+		r, b = chroma, x
+	}
+	m := lightness - chroma/2 //nolint:mnd // Centre on the lightness
+	return color.RGBA{
+		R: uint8(math.Round((r + m) * 255)), //nolint:mnd // Full channel
+		G: uint8(math.Round((g + m) * 255)), //nolint:mnd // Full channel
+		B: uint8(math.Round((b + m) * 255)), //nolint:mnd // Full channel
+		A: 255,                              //nolint:mnd // Opaque
+	}
+}
+
+// antennaGrid places every antenna's frequency on a grid the size of the map.
+func antennaGrid(dimensions lib.Coord, antennae map[rune][]lib.Coord) [][]rune {
+	grid := make([][]rune, dimensions.Row)
+	for iRow := range grid {
+		grid[iRow] = make([]rune, dimensions.Col)
+	}
+	for freq, locs := range antennae {
+		for _, loc := range locs {
+			grid[loc.Row][loc.Col] = freq
+		}
+	}
+	return grid
+}
+
+// antinodeColor colours an antinode after the frequency producing it, or white when several frequencies share it.
+func antinodeColor(pairs []lib.Pair, palette map[rune]color.RGBA) (color.RGBA, bool) {
+	for _, pair := range pairs[1:] {
+		if pair.Frequency != pairs[0].Frequency {
+			return sharedColor, true
+		}
+	}
+	return palette[pairs[0].Frequency], false
+}
+
+// renderText writes the map, marking antinodes and their overlaps. With a palette, antennae and antinodes are coloured
+// by frequency and antinodes on top of an antenna are shown in reverse video instead of with overlapMark.
+func renderText(
+	out io.Writer, dimensions lib.Coord, antennae map[rune][]lib.Coord, antinodes map[lib.Coord][]lib.Pair,
+	palette map[rune]color.RGBA,
+) error {
+	w := bufio.NewWriter(out)
+	grid := antennaGrid(dimensions, antennae)
+	for iRow, row := range grid {
+		for iCol, freq := range row {
+			pairs, isAntinode := antinodes[lib.Coord{Row: iRow, Col: iCol}]
+			mark := freq
+			switch {
+			case !isAntinode && freq == 0:
+				mark = emptyMark
+			case !isAntinode:
+			case freq != 0 && palette == nil:
+				mark = overlapMark
+			case freq != 0:
+			default:
+				mark = antinodeMark
+				if _, shared := antinodeColor(pairs, palette); shared {
+					mark = sharedMark
+				}
+			}
+			if palette == nil || mark == emptyMark {
+				w.WriteRune(mark)
+				continue
+			}
+
+			shade := palette[freq]
+			if freq == 0 {
+				shade, _ = antinodeColor(pairs, palette)
+			}
+			fmt.Fprintf(w, ansiForegroundRGB, shade.R, shade.G, shade.B)
+			if freq != 0 && isAntinode {
+				w.WriteString(ansiReverse)
+			}
+			w.WriteRune(mark)
+			w.WriteString(ansiReset)
+		}
+		w.WriteByte('\n')
+	}
+	return w.Flush() //nolint:wrapcheck // Toy code
+}
+
+// writePNGFile draws antennae as filled cells in their frequency's colour and antinodes as smaller squares; antinodes on
+// top of an antenna get a dot in the antinode's colour.
+func writePNGFile(
+	path string, dimensions lib.Coord, antennae map[rune][]lib.Coord, antinodes map[lib.Coord][]lib.Pair,
+	palette map[rune]color.RGBA,
+) (err error) {
+	img := image.NewRGBA(image.Rect(0, 0, dimensions.Col*pngCellSize, dimensions.Row*pngCellSize))
+	draw.Draw(img, img.Bounds(), image.NewUniform(backgroundColor), image.Point{}, draw.Src)
+	cell := func(coord lib.Coord, inset int) image.Rectangle {
+		return image.Rect(coord.Col*pngCellSize, coord.Row*pngCellSize,
+			(coord.Col+1)*pngCellSize, (coord.Row+1)*pngCellSize).Inset(inset)
+	}
+	for freq, locs := range antennae {
+		for _, loc := range locs {
+			draw.Draw(img, cell(loc, 0), image.NewUniform(palette[freq]), image.Point{}, draw.Src)
+		}
+	}
+	grid := antennaGrid(dimensions, antennae)
+	for coord, pairs := range antinodes {
+		shade, _ := antinodeColor(pairs, palette)
+		inset := pngCellSize / 4 //nolint:mnd // Half-size square
+		if grid[coord.Row][coord.Col] != 0 {
+			inset = pngCellSize * 3 / 8 //nolint:mnd // Quarter-size dot
+			if shade == palette[grid[coord.Row][coord.Col]] {
+				shade = sharedColor
+			}
+		}
+		draw.Draw(img, cell(coord, inset), image.NewUniform(shade), image.Point{}, draw.Src)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	return png.Encode(file, img) //nolint:wrapcheck // Toy code
+}
+
+func readInput(args Args, input io.Reader, out *report.Report) (lib.Coord, map[rune][]lib.Coord, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	readArray := lib.ReadArray
+	if args.Sparse {
+		readArray = lib.ReadSparse
+	}
+	dimensions, antennae, err := readArray(scanner)
+
+	return dimensions, antennae, err //nolint:wrapcheck // Toy code
+}
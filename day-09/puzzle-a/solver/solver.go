@@ -0,0 +1,60 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"math/big"
+
+	"common/command"
+	"common/report"
+	"daynine/a/lib"
+
+	"github.com/samber/lo"
+)
+
+type Args struct {
+	command.Flags
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(_ context.Context, _ Args, input io.Reader, out *report.Report) error {
+	scanner := bufio.NewScanner(out.CountInput(input))
+
+	diskContents, err := lib.ReadInput(scanner)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+	slog.Debug("read disk contents", "length", len(diskContents))
+
+	freeSpacePtr := lo.IndexOf(diskContents, lib.FreeSpaceIndicator)
+	usedPredicate := func(val int) bool {
+		return val != lib.FreeSpaceIndicator
+	}
+	_, lastFilledPtr, _ := lo.FindLastIndexOf(diskContents, usedPredicate)
+	for freeSpacePtr != -1 && lastFilledPtr != -1 {
+		if freeSpacePtr >= lastFilledPtr {
+			break
+		}
+
+		diskContents[freeSpacePtr], diskContents[lastFilledPtr] = diskContents[lastFilledPtr], diskContents[freeSpacePtr]
+		freeSpacePtr += lo.IndexOf(diskContents[freeSpacePtr:], lib.FreeSpaceIndicator)
+		_, lastFilledPtr, _ = lo.FindLastIndexOf(diskContents[:lastFilledPtr], usedPredicate)
+	}
+
+	checkSum := big.NewInt(0)
+	for i, val := range diskContents {
+		if val == lib.FreeSpaceIndicator {
+			continue
+		}
+		checkSum.Add(checkSum, big.NewInt(int64(i*val)))
+	}
+
+	slog.Info("solved", "checksum", checkSum.String())
+	out.Parsed("blocks", len(diskContents))
+	out.Answer("checksum", checkSum)
+
+	return nil
+}
@@ -3,18 +3,25 @@ package lib
 import (
 	"bufio"
 
+	"common/parsing"
+
 	"github.com/samber/lo"
 )
 
 const FreeSpaceIndicator = -1
 
-func ReadInput(scanner *bufio.Scanner) []int {
+func ReadInput(scanner *bufio.Scanner) ([]int, error) {
 	diskContents := make([]int, 0)
+	lineNum := 0
 	for scanner.Scan() {
+		lineNum++
 		line := scanner.Text()
 		nextValIsFreeSpace := false
 		fileID := 0
-		for _, c := range line {
+		for iByte, c := range line {
+			if c < '0' || c > '9' {
+				return nil, parsing.Errorf(lineNum, iByte+1, string(c), "expected a digit")
+			}
 			val := int(c - '0')
 			if nextValIsFreeSpace {
 				diskContents = append(diskContents, RunOf(val, FreeSpaceIndicator)...)
@@ -25,8 +32,11 @@ func ReadInput(scanner *bufio.Scanner) []int {
 			nextValIsFreeSpace = !nextValIsFreeSpace
 		}
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
 
-	return diskContents
+	return diskContents, nil
 }
 
 func RunOf[T any](runLength int, val T) []T {
@@ -0,0 +1,42 @@
+package lib
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readDisk(input string) ([]int, error) {
+	return ReadInput(bufio.NewScanner(strings.NewReader(input)))
+}
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("2333133121414131402\n")
+	f.Add("12345\n")
+	f.Add("1010\n\n23\n")
+	f.Add("12x45\n")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, input string) {
+		diskContents, err := readDisk(input)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+
+		// The digits are run lengths, alternately of a file and of free space.
+		length := 0
+		for _, c := range input {
+			if c >= '0' && c <= '9' {
+				length += int(c - '0')
+			}
+		}
+		assert.Len(t, diskContents, length)
+		for _, block := range diskContents {
+			assert.True(t, block == FreeSpaceIndicator || block >= 0, block)
+		}
+	})
+}
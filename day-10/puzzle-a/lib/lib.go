@@ -2,8 +2,18 @@ package lib
 
 import (
 	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"common/parsing"
 
 	"github.com/hashicorp/go-set/v3"
+	"github.com/samber/lo"
 )
 
 type Coord struct {
@@ -37,7 +47,29 @@ func (c Coord) Subtract(other Coord) Coord {
 
 const TopElevation = 9
 const BottomElevation = 0
-const InvalidElevation = -1
+const InvalidElevation = math.MinInt
+
+const (
+	DigitsEncoding  = "digits"
+	LettersEncoding = "letters"
+	FieldsEncoding  = "fields"
+)
+
+// Encoding describes how a map is written. With the digits and letters encodings every character is a cell, `0`-`9`
+// and `a`-`z` standing for elevations from zero up; with the fields encoding every comma- or space-separated integer
+// is a cell. Cells matching one of the impassable markers cannot be stepped onto. Trails go from the trailhead
+// elevation up to the peak elevation, which default to the lowest and highest elevations of the encoding (or of the
+// map, for the fields encoding).
+type Encoding struct {
+	Name       string
+	Impassable []string
+	Trailhead  *int
+	Peak       *int
+}
+
+func DefaultEncoding() Encoding {
+	return Encoding{Name: DigitsEncoding, Impassable: []string{"."}}
+}
 
 type Cell struct {
 	Elevation      int
@@ -47,29 +79,137 @@ type Cell struct {
 type Board struct {
 	Grid        [][]Cell
 	ByElevation map[int][]Coord
+	Trailhead   int
+	Peak        int
 }
 
-func ReadInput(scanner *bufio.Scanner) Board {
+func ReadInput(scanner *bufio.Scanner, encoding Encoding) (Board, error) {
 	var board Board
 	board.Grid = make([][]Cell, 0)
 	board.ByElevation = make(map[int][]Coord)
+	iLine := 0
 	for scanner.Scan() {
 		line := scanner.Text()
-		iRow := len(board.Grid)
-		board.Grid = append(board.Grid, make([]Cell, len(line)))
-		for iCol, c := range line {
-			elevation := int(c - '0')
-			if elevation < BottomElevation || elevation > TopElevation {
-				elevation = InvalidElevation
+		iLine++
+		tokens, cols := splitLine(line, encoding)
+		if len(tokens) < 1 {
+			continue
+		}
+		if len(board.Grid) > 0 && len(tokens) != len(board.Grid[0]) {
+			width := len(board.Grid[0])
+			if len(tokens) > width {
+				return Board{}, parsing.Errorf(iLine, cols[width], tokens[width], "line has %d cells instead of %d", len(tokens), width)
 			}
-			reachablePeaks := set.New[Coord](0)
-			if elevation == TopElevation {
-				reachablePeaks.Insert(Coord{Row: iRow, Col: iCol})
+			return Board{}, parsing.Errorf(iLine, len(line)+1, "", "line has %d cells instead of %d", len(tokens), width)
+		}
+
+		iRow := len(board.Grid)
+		board.Grid = append(board.Grid, make([]Cell, len(tokens)))
+		for iCol, token := range tokens {
+			elevation, err := parseElevation(token, encoding)
+			if err != nil {
+				return Board{}, &parsing.Error{Line: iLine, Col: cols[iCol], Text: token, Err: err}
 			}
-			board.Grid[iRow][iCol] = Cell{Elevation: elevation, ReachablePeaks: reachablePeaks}
+			board.Grid[iRow][iCol] = Cell{Elevation: elevation, ReachablePeaks: set.New[Coord](0)}
 			board.ByElevation[elevation] = append(board.ByElevation[elevation], Coord{Row: iRow, Col: iCol})
 		}
 	}
 
-	return board
+	elevations := board.Elevations()
+	switch {
+	case encoding.Trailhead != nil:
+		board.Trailhead = *encoding.Trailhead
+	case encoding.Name == FieldsEncoding && len(elevations) > 0:
+		board.Trailhead = elevations[0]
+	default:
+		board.Trailhead = BottomElevation
+	}
+	switch {
+	case encoding.Peak != nil:
+		board.Peak = *encoding.Peak
+	case encoding.Name == FieldsEncoding && len(elevations) > 0:
+		board.Peak = elevations[len(elevations)-1]
+	case encoding.Name == LettersEncoding:
+		board.Peak = 'z' - 'a'
+	default:
+		board.Peak = TopElevation
+	}
+	if board.Trailhead >= board.Peak {
+		return Board{}, fmt.Errorf("trailhead elevation %d is not below peak elevation %d", board.Trailhead, board.Peak)
+	}
+
+	for _, coord := range board.ByElevation[board.Peak] {
+		board.Grid[coord.Row][coord.Col].ReachablePeaks.Insert(coord)
+	}
+	delete(board.ByElevation, InvalidElevation)
+
+	return board, nil
+}
+
+// Elevations returns the distinct elevations found on the board, lowest first.
+func (b *Board) Elevations() []int {
+	elevations := lo.Without(lo.Keys(b.ByElevation), InvalidElevation)
+	slices.Sort(elevations)
+
+	return elevations
+}
+
+// ElevationsBetween returns the distinct elevations found on the board from low to high, both included, lowest first.
+func (b *Board) ElevationsBetween(low, high int) []int {
+	return lo.Filter(b.Elevations(), func(elevation int, _ int) bool {
+		return elevation >= low && elevation <= high
+	})
+}
+
+// splitLine splits the line into one token per cell, also returning the 1-based column at which every token starts.
+func splitLine(line string, encoding Encoding) ([]string, []int) {
+	var tokens []string
+	var cols []int
+	if encoding.Name != FieldsEncoding {
+		for iByte, r := range strings.TrimRightFunc(line, unicode.IsSpace) {
+			tokens = append(tokens, string(r))
+			cols = append(cols, iByte+1)
+		}
+		return tokens, cols
+	}
+
+	start := -1
+	for iByte, r := range line + " " {
+		isSeparator := r == ',' || unicode.IsSpace(r)
+		switch {
+		case isSeparator && start >= 0:
+			tokens = append(tokens, line[start:iByte])
+			cols = append(cols, start+1)
+			start = -1
+		case !isSeparator && start < 0:
+			start = iByte
+		}
+	}
+	return tokens, cols
+}
+
+func parseElevation(token string, encoding Encoding) (int, error) {
+	if slices.Contains(encoding.Impassable, token) {
+		return InvalidElevation, nil
+	}
+
+	switch encoding.Name {
+	case DigitsEncoding:
+		if len(token) == 1 && token[0] >= '0' && token[0] <= '9' {
+			return int(token[0] - '0'), nil
+		}
+	case LettersEncoding:
+		if len(token) == 1 && token[0] >= 'a' && token[0] <= 'z' {
+			return int(token[0] - 'a'), nil
+		}
+	case FieldsEncoding:
+		elevation, err := strconv.Atoi(token)
+		if err == nil && elevation != InvalidElevation {
+			return elevation, nil
+		}
+	default:
+		return InvalidElevation, fmt.Errorf("unrecognized encoding: %s", encoding.Name)
+	}
+
+	return InvalidElevation, errors.New("unrecognized elevation")
 }
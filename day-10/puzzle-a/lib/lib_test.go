@@ -0,0 +1,44 @@
+package lib
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("0123\n1234\n8765\n9876\n", false)
+	f.Add("89010123\n78121874\n87430965\n96549874\n45678903\n32019012\n01329801\n10456732\n", false)
+	f.Add("..90..9\n...1.98\n...2..7\n6543456\n765.987\n876....\n987....\n", false)
+	f.Add("abc\nzyx\n", true)
+	f.Add("012\n34\n", false)
+	f.Add("012\n3456\n", false)
+	f.Add("0x2\n", false)
+	f.Add("\n\n01\n\n", false)
+	f.Add("0é1\n", true)
+	f.Fuzz(func(t *testing.T, input string, letters bool) {
+		encoding := DefaultEncoding()
+		if letters {
+			encoding.Name = LettersEncoding
+		}
+		board, err := ReadInput(bufio.NewScanner(strings.NewReader(input)), encoding)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+		for _, row := range board.Grid {
+			assert.Len(t, row, len(board.Grid[0]))
+		}
+		for elevation, coords := range board.ByElevation {
+			assert.GreaterOrEqual(t, elevation, board.Trailhead)
+			assert.LessOrEqual(t, elevation, board.Peak)
+			for _, coord := range coords {
+				assert.Equal(t, elevation, board.Grid[coord.Row][coord.Col].Elevation)
+			}
+		}
+	})
+}
@@ -0,0 +1,97 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+
+	"common/command"
+	"common/report"
+	"dayten/a/lib"
+
+	"github.com/hashicorp/go-set/v3"
+)
+
+type Args struct {
+	command.Flags
+
+	Encoding           string   `arg:"--encoding"             default:"digits" help:"map encoding: digits, letters or fields"`
+	Impassable         []string `arg:"--impassable,separate"  help:"marker of impassable cells (repeatable; default: .)"`
+	TrailheadElevation *int     `arg:"--trailhead-elevation"  help:"elevation of trailheads"`
+	PeakElevation      *int     `arg:"--peak-elevation"       help:"elevation of peaks"`
+}
+
+var directions = []lib.Coord{{Row: 1, Col: 0}, {Row: 0, Col: 1}, {Row: 0, Col: -1}, {Row: -1, Col: 0}} //nolint:gochecknoglobals // Meant as a constant
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	board, err := readInput(args, input, out)
+	if err != nil {
+		return err
+	}
+	if len(board.Grid) < 1 {
+		return errors.New("grid is empty")
+	}
+
+	dimensions := lib.Coord{Row: len(board.Grid), Col: len(board.Grid[0])}
+	totalScore := 0
+	elevations := board.ElevationsBetween(board.Trailhead, board.Peak-1)
+	for _, elevation := range slices.Backward(elevations) {
+		err = ctx.Err()
+		if err != nil {
+			return err //nolint:wrapcheck // Toy code
+		}
+
+		for _, coord := range board.ByElevation[elevation] {
+			reachablePeaks := set.New[lib.Coord](0)
+			for _, dir := range directions {
+				neighborCoord := coord.Add(lib.Coord{Row: dir.Row, Col: dir.Col})
+				if !neighborCoord.IsValid(dimensions) {
+					continue
+				}
+
+				neighborCell := &board.Grid[neighborCoord.Row][neighborCoord.Col]
+				if neighborCell.Elevation != elevation+1 {
+					continue
+				}
+
+				reachablePeaks.InsertSet(neighborCell.ReachablePeaks)
+			}
+
+			cell := &board.Grid[coord.Row][coord.Col]
+			cell.ReachablePeaks = reachablePeaks
+			if elevation > board.Trailhead {
+				continue
+			}
+
+			totalScore += reachablePeaks.Size()
+		}
+	}
+
+	slog.Debug("read grid", "dimensions", dimensions)
+	slog.Info("solved", "totalScore", totalScore)
+	out.Parsed("rows", dimensions.Row)
+	out.Parsed("cols", dimensions.Col)
+	out.Answer("totalScore", totalScore)
+
+	return nil
+}
+
+func readInput(args Args, input io.Reader, out *report.Report) (lib.Board, error) {
+	encoding := lib.DefaultEncoding()
+	encoding.Name = args.Encoding
+	if len(args.Impassable) > 0 {
+		encoding.Impassable = args.Impassable
+	}
+	encoding.Trailhead = args.TrailheadElevation
+	encoding.Peak = args.PeakElevation
+
+	scanner := bufio.NewScanner(out.CountInput(input))
+	board, err := lib.ReadInput(scanner, encoding)
+
+	return board, err //nolint:wrapcheck // Toy code
+}
@@ -2,8 +2,20 @@ package lib
 
 import (
 	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
 
-	"github.com/hashicorp/go-set/v3"
+	"common/parsing"
+
+	"github.com/samber/lo"
 )
 
 type Coord struct {
@@ -37,7 +49,29 @@ func (c Coord) Subtract(other Coord) Coord {
 
 const TopElevation = 9
 const BottomElevation = 0
-const InvalidElevation = -1
+const InvalidElevation = math.MinInt
+
+const (
+	DigitsEncoding  = "digits"
+	LettersEncoding = "letters"
+	FieldsEncoding  = "fields"
+)
+
+// Encoding describes how a map is written. With the digits and letters encodings every character is a cell, `0`-`9`
+// and `a`-`z` standing for elevations from zero up; with the fields encoding every comma- or space-separated integer
+// is a cell. Cells matching one of the impassable markers cannot be stepped onto. Trails go from the trailhead
+// elevation up to the peak elevation, which default to the lowest and highest elevations of the encoding (or of the
+// map, for the fields encoding).
+type Encoding struct {
+	Name       string
+	Impassable []string
+	Trailhead  *int
+	Peak       *int
+}
+
+func DefaultEncoding() Encoding {
+	return Encoding{Name: DigitsEncoding, Impassable: []string{"."}}
+}
 
 type Cell struct {
 	Elevation  int
@@ -47,31 +81,381 @@ type Cell struct {
 type Board struct {
 	Grid        [][]Cell
 	ByElevation map[int][]Coord
+	Trailhead   int
+	Peak        int
 }
 
-func ReadInput(scanner *bufio.Scanner) Board {
+func ReadInput(scanner *bufio.Scanner, encoding Encoding) (Board, error) {
 	var board Board
 	board.Grid = make([][]Cell, 0)
 	board.ByElevation = make(map[int][]Coord)
+	iLine := 0
 	for scanner.Scan() {
 		line := scanner.Text()
+		iLine++
+		tokens, cols := splitLine(line, encoding)
+		if len(tokens) < 1 {
+			continue
+		}
+		if len(board.Grid) > 0 && len(tokens) != len(board.Grid[0]) {
+			width := len(board.Grid[0])
+			if len(tokens) > width {
+				return Board{}, parsing.Errorf(iLine, cols[width], tokens[width], "line has %d cells instead of %d", len(tokens), width)
+			}
+			return Board{}, parsing.Errorf(iLine, len(line)+1, "", "line has %d cells instead of %d", len(tokens), width)
+		}
+
 		iRow := len(board.Grid)
-		board.Grid = append(board.Grid, make([]Cell, len(line)))
-		for iCol, c := range line {
-			elevation := int(c - '0')
-			if elevation < BottomElevation || elevation > TopElevation {
-				elevation = InvalidElevation
+		board.Grid = append(board.Grid, make([]Cell, len(tokens)))
+		for iCol, token := range tokens {
+			elevation, err := parseElevation(token, encoding)
+			if err != nil {
+				return Board{}, &parsing.Error{Line: iLine, Col: cols[iCol], Text: token, Err: err}
 			}
-			reachablePeaks := set.New[Coord](0)
+			board.Grid[iRow][iCol] = Cell{Elevation: elevation}
+			board.ByElevation[elevation] = append(board.ByElevation[elevation], Coord{Row: iRow, Col: iCol})
+		}
+	}
+
+	elevations := board.Elevations()
+	switch {
+	case encoding.Trailhead != nil:
+		board.Trailhead = *encoding.Trailhead
+	case encoding.Name == FieldsEncoding && len(elevations) > 0:
+		board.Trailhead = elevations[0]
+	default:
+		board.Trailhead = BottomElevation
+	}
+	switch {
+	case encoding.Peak != nil:
+		board.Peak = *encoding.Peak
+	case encoding.Name == FieldsEncoding && len(elevations) > 0:
+		board.Peak = elevations[len(elevations)-1]
+	case encoding.Name == LettersEncoding:
+		board.Peak = 'z' - 'a'
+	default:
+		board.Peak = TopElevation
+	}
+	if board.Trailhead >= board.Peak {
+		return Board{}, fmt.Errorf("trailhead elevation %d is not below peak elevation %d", board.Trailhead, board.Peak)
+	}
+
+	for _, coord := range board.ByElevation[board.Peak] {
+		board.Grid[coord.Row][coord.Col].TrailCount = 1
+	}
+	delete(board.ByElevation, InvalidElevation)
+
+	return board, nil
+}
+
+// Elevations returns the distinct elevations found on the board, lowest first.
+func (b *Board) Elevations() []int {
+	elevations := lo.Without(lo.Keys(b.ByElevation), InvalidElevation)
+	slices.Sort(elevations)
+
+	return elevations
+}
+
+// ElevationsBetween returns the distinct elevations found on the board from low to high, both included, lowest first.
+func (b *Board) ElevationsBetween(low, high int) []int {
+	return lo.Filter(b.Elevations(), func(elevation int, _ int) bool {
+		return elevation >= low && elevation <= high
+	})
+}
+
+// splitLine splits the line into one token per cell, also returning the 1-based column at which every token starts.
+func splitLine(line string, encoding Encoding) ([]string, []int) {
+	var tokens []string
+	var cols []int
+	if encoding.Name != FieldsEncoding {
+		for iByte, r := range strings.TrimRightFunc(line, unicode.IsSpace) {
+			tokens = append(tokens, string(r))
+			cols = append(cols, iByte+1)
+		}
+		return tokens, cols
+	}
+
+	start := -1
+	for iByte, r := range line + " " {
+		isSeparator := r == ',' || unicode.IsSpace(r)
+		switch {
+		case isSeparator && start >= 0:
+			tokens = append(tokens, line[start:iByte])
+			cols = append(cols, start+1)
+			start = -1
+		case !isSeparator && start < 0:
+			start = iByte
+		}
+	}
+	return tokens, cols
+}
+
+func parseElevation(token string, encoding Encoding) (int, error) {
+	if slices.Contains(encoding.Impassable, token) {
+		return InvalidElevation, nil
+	}
+
+	switch encoding.Name {
+	case DigitsEncoding:
+		if len(token) == 1 && token[0] >= '0' && token[0] <= '9' {
+			return int(token[0] - '0'), nil
+		}
+	case LettersEncoding:
+		if len(token) == 1 && token[0] >= 'a' && token[0] <= 'z' {
+			return int(token[0] - 'a'), nil
+		}
+	case FieldsEncoding:
+		elevation, err := strconv.Atoi(token)
+		if err == nil && elevation != InvalidElevation {
+			return elevation, nil
+		}
+	default:
+		return InvalidElevation, fmt.Errorf("unrecognized encoding: %s", encoding.Name)
+	}
+
+	return InvalidElevation, errors.New("unrecognized elevation")
+}
+
+// StepRules describes the steps a hiker may take: the elevation gains allowed in a single step, and whether diagonal
+// neighbours can be stepped onto. Every step must go up, so that trails never walk in circles and the trail counts can
+// be computed one elevation at a time.
+type StepRules struct {
+	Climbs   []int
+	Diagonal bool
+}
+
+func DefaultStepRules() StepRules {
+	return StepRules{Climbs: []int{1}}
+}
+
+var orthogonalDirections = []Coord{ //nolint:gochecknoglobals // Meant as a constant
+	{Row: 1, Col: 0},
+	{Row: 0, Col: 1},
+	{Row: 0, Col: -1},
+	{Row: -1, Col: 0},
+}
+
+var diagonalDirections = []Coord{ //nolint:gochecknoglobals // Meant as a constant
+	{Row: 1, Col: 1},
+	{Row: 1, Col: -1},
+	{Row: -1, Col: 1},
+	{Row: -1, Col: -1},
+}
+
+// Validate checks that every climb goes up.
+func (r StepRules) Validate() error {
+	if len(r.Climbs) < 1 {
+		return errors.New("no climb allowed")
+	}
+	for _, climb := range r.Climbs {
+		if climb <= 0 {
+			return fmt.Errorf("invalid climb %d: every step must go up", climb)
+		}
+	}
+
+	return nil
+}
+
+func (r StepRules) Directions() []Coord {
+	if r.Diagonal {
+		return append(slices.Clone(orthogonalDirections), diagonalDirections...)
+	}
+
+	return orthogonalDirections
+}
+
+func (r StepRules) CanStep(fromElevation, toElevation int) bool {
+	return fromElevation != InvalidElevation && toElevation != InvalidElevation &&
+		slices.Contains(r.Climbs, toElevation-fromElevation)
+}
+
+func (b *Board) Dimensions() Coord {
+	if len(b.Grid) < 1 {
+		return Coord{}
+	}
+
+	return Coord{Row: len(b.Grid), Col: len(b.Grid[0])}
+}
+
+// Steps returns the cells a hiker can step onto from the given cell.
+func (b *Board) Steps(coord Coord, rules StepRules) []Coord {
+	dimensions := b.Dimensions()
+	elevation := b.Grid[coord.Row][coord.Col].Elevation
+	var steps []Coord
+	for _, dir := range rules.Directions() {
+		neighborCoord := coord.Add(dir)
+		if !neighborCoord.IsValid(dimensions) {
+			continue
+		}
+		if !rules.CanStep(elevation, b.Grid[neighborCoord.Row][neighborCoord.Col].Elevation) {
+			continue
+		}
+		steps = append(steps, neighborCoord)
+	}
+
+	return steps
+}
+
+// ErrTooManyTrails is returned when a number of trails does not fit in an int, which takes a map with many elevations.
+var ErrTooManyTrails = errors.New("too many trails to count")
+
+// addTrails adds two numbers of trails, which are never negative.
+func addTrails(a, b int) (int, error) {
+	if a > math.MaxInt-b {
+		return 0, ErrTooManyTrails
+	}
+
+	return a + b, nil
+}
+
+// mulTrails multiplies two numbers of trails, which are never negative.
+func mulTrails(a, b int) (int, error) {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	if hi != 0 || lo > math.MaxInt {
+		return 0, ErrTooManyTrails
+	}
+
+	return int(lo), nil
+}
+
+// CountTrails sets the trail count of every cell to the number of distinct trails from it to a peak, going down the
+// elevations so that every step lands on a cell whose count is already known. It stops once ctx is cancelled.
+func (b *Board) CountTrails(ctx context.Context, rules StepRules) error {
+	err := rules.Validate()
+	if err != nil {
+		return err
+	}
+
+	elevations := b.ElevationsBetween(b.Trailhead, b.Peak-1)
+	for _, elevation := range slices.Backward(elevations) {
+		err = ctx.Err()
+		if err != nil {
+			return err //nolint:wrapcheck // Toy code
+		}
+
+		for _, coord := range b.ByElevation[elevation] {
 			trailCount := 0
-			if elevation == TopElevation {
-				reachablePeaks.Insert(Coord{Row: iRow, Col: iCol})
-				trailCount = 1
+			for _, neighborCoord := range b.Steps(coord, rules) {
+				trailCount, err = addTrails(trailCount, b.Grid[neighborCoord.Row][neighborCoord.Col].TrailCount)
+				if err != nil {
+					return err
+				}
 			}
-			board.Grid[iRow][iCol] = Cell{Elevation: elevation, TrailCount: trailCount}
-			board.ByElevation[elevation] = append(board.ByElevation[elevation], Coord{Row: iRow, Col: iCol})
+			b.Grid[coord.Row][coord.Col].TrailCount = trailCount
+		}
+	}
+
+	return nil
+}
+
+// TotalTrails returns the number of distinct trails from any trailhead to any peak. CountTrails must have been called.
+func (b *Board) TotalTrails() (int, error) {
+	total := 0
+	for _, coord := range b.ByElevation[b.Trailhead] {
+		var err error
+		total, err = addTrails(total, b.Grid[coord.Row][coord.Col].TrailCount)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return total, nil
+}
+
+// Trails returns up to limit distinct trails from the trailhead to a peak, each as the list of cells walked through.
+// CountTrails must have been called with the same rules.
+func (b *Board) Trails(trailhead Coord, rules StepRules, limit int) [][]Coord {
+	var trails [][]Coord
+	var walk func(trail []Coord)
+	walk = func(trail []Coord) {
+		if len(trails) >= limit {
+			return
+		}
+
+		coord := trail[len(trail)-1]
+		if b.Grid[coord.Row][coord.Col].Elevation == b.Peak {
+			trails = append(trails, slices.Clone(trail))
+			return
+		}
+
+		for _, neighborCoord := range b.Steps(coord, rules) {
+			if b.Grid[neighborCoord.Row][neighborCoord.Col].TrailCount < 1 {
+				continue
+			}
+			walk(append(trail, neighborCoord))
+		}
+	}
+	walk([]Coord{trailhead})
+
+	return trails
+}
+
+// SampleTrail returns a trail from the trailhead to a peak drawn uniformly among all such trails, or nil if there is
+// none. CountTrails must have been called with the same rules.
+func (b *Board) SampleTrail(trailhead Coord, rules StepRules, rng *rand.Rand) []Coord {
+	if b.Grid[trailhead.Row][trailhead.Col].TrailCount < 1 {
+		return nil
+	}
+
+	trail := []Coord{trailhead}
+	coord := trailhead
+	for b.Grid[coord.Row][coord.Col].Elevation != b.Peak {
+		pick := rng.IntN(b.Grid[coord.Row][coord.Col].TrailCount)
+		for _, neighborCoord := range b.Steps(coord, rules) {
+			neighborCount := b.Grid[neighborCoord.Row][neighborCoord.Col].TrailCount
+			if pick < neighborCount {
+				coord = neighborCoord
+				break
+			}
+			pick -= neighborCount
+		}
+		trail = append(trail, coord)
+	}
+
+	return trail
+}
+
+// HeatMap returns, for every cell, the number of trails from any trailhead to any peak that pass through it: the
+// number of ways to reach the cell from a trailhead times the number of ways to go on from it to a peak. CountTrails
+// must have been called with the same rules. It stops once ctx is cancelled.
+func (b *Board) HeatMap(ctx context.Context, rules StepRules) ([][]int, error) {
+	dimensions := b.Dimensions()
+	fromTrailheads := make([][]int, dimensions.Row)
+	for iRow := range fromTrailheads {
+		fromTrailheads[iRow] = make([]int, dimensions.Col)
+	}
+	for _, coord := range b.ByElevation[b.Trailhead] {
+		fromTrailheads[coord.Row][coord.Col] = 1
+	}
+
+	for _, elevation := range b.ElevationsBetween(b.Trailhead, b.Peak-1) {
+		err := ctx.Err()
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Toy code
+		}
+
+		for _, coord := range b.ByElevation[elevation] {
+			for _, neighborCoord := range b.Steps(coord, rules) {
+				sum, err := addTrails(fromTrailheads[neighborCoord.Row][neighborCoord.Col], fromTrailheads[coord.Row][coord.Col])
+				if err != nil {
+					return nil, err
+				}
+				fromTrailheads[neighborCoord.Row][neighborCoord.Col] = sum
+			}
+		}
+	}
+
+	heatMap := make([][]int, dimensions.Row)
+	for iRow := range heatMap {
+		heatMap[iRow] = make([]int, dimensions.Col)
+		for iCol := range heatMap[iRow] {
+			heat, err := mulTrails(fromTrailheads[iRow][iCol], b.Grid[iRow][iCol].TrailCount)
+			if err != nil {
+				return nil, err
+			}
+			heatMap[iRow][iCol] = heat
 		}
 	}
 
-	return board
+	return heatMap, nil
 }
@@ -0,0 +1,114 @@
+package lib
+
+import (
+	"bufio"
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("0123\n1234\n8765\n9876\n", false)
+	f.Add("89010123\n78121874\n87430965\n96549874\n45678903\n32019012\n01329801\n10456732\n", false)
+	f.Add("..90..9\n...1.98\n...2..7\n6543456\n765.987\n876....\n987....\n", false)
+	f.Add("abc\nzyx\n", true)
+	f.Add("012\n34\n", false)
+	f.Add("012\n3456\n", false)
+	f.Add("0x2\n", false)
+	f.Add("\n\n01\n\n", false)
+	f.Add("0é1\n", true)
+	f.Fuzz(func(t *testing.T, input string, letters bool) {
+		encoding := DefaultEncoding()
+		if letters {
+			encoding.Name = LettersEncoding
+		}
+		board, err := ReadInput(bufio.NewScanner(strings.NewReader(input)), encoding)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+		for _, row := range board.Grid {
+			assert.Len(t, row, len(board.Grid[0]))
+		}
+		for elevation, coords := range board.ByElevation {
+			assert.GreaterOrEqual(t, elevation, board.Trailhead)
+			assert.LessOrEqual(t, elevation, board.Peak)
+			for _, coord := range coords {
+				assert.Equal(t, elevation, board.Grid[coord.Row][coord.Col].Elevation)
+			}
+		}
+	})
+}
+
+func readBoard(t *testing.T, input string, encoding Encoding) Board {
+	t.Helper()
+	board, err := ReadInput(bufio.NewScanner(strings.NewReader(input)), encoding)
+	require.NoError(t, err)
+	return board
+}
+
+func TestStepRulesValidate(t *testing.T) {
+	require.NoError(t, DefaultStepRules().Validate())
+	require.NoError(t, StepRules{Climbs: []int{1, 2}, Diagonal: true}.Validate())
+	assert.EqualError(t, StepRules{}.Validate(), "no climb allowed")
+	assert.EqualError(t, StepRules{Climbs: []int{0, 1, 2}}.Validate(), "invalid climb 0: every step must go up")
+	assert.EqualError(t, StepRules{Climbs: []int{1, -1}}.Validate(), "invalid climb -1: every step must go up")
+
+	// A flat step would let trails go back and forth between the two trailheads.
+	board := readBoard(t, "0023456789\n", DefaultEncoding())
+	require.EqualError(t, board.CountTrails(context.Background(), StepRules{Climbs: []int{0, 1, 2}}), "invalid climb 0: every step must go up")
+	require.NoError(t, board.CountTrails(context.Background(), StepRules{Climbs: []int{1, 2}}))
+	total, err := board.TotalTrails()
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+}
+
+func TestCountTrails(t *testing.T) {
+	encoding := DefaultEncoding()
+	encoding.Name = FieldsEncoding
+	rules := StepRules{Climbs: []int{1}, Diagonal: true}
+	board := readBoard(t, "0 1 2\n0 1 2\n0 1 2\n", encoding)
+	require.NoError(t, board.CountTrails(context.Background(), rules))
+	total, err := board.TotalTrails()
+	require.NoError(t, err)
+	assert.Equal(t, 5+7+5, total)
+
+	heatMap, err := board.HeatMap(context.Background(), rules)
+	require.NoError(t, err)
+	assert.Equal(t, [][]int{{5, 4, 5}, {7, 9, 7}, {5, 4, 5}}, heatMap)
+
+	// Counting stops once cancelled.
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, board.CountTrails(cancelled, rules), context.Canceled)
+	_, err = board.HeatMap(cancelled, rules)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCountTrailsOverflow(t *testing.T) {
+	encoding := DefaultEncoding()
+	encoding.Name = FieldsEncoding
+	rules := StepRules{Climbs: []int{1}, Diagonal: true}
+
+	// The number of trails grows about 2.4 times with every column.
+	row := make([]string, 60)
+	for i := range row {
+		row[i] = strconv.Itoa(i)
+	}
+	line := strings.Join(row, " ") + "\n"
+	board := readBoard(t, line+line+line, encoding)
+	require.ErrorIs(t, board.CountTrails(context.Background(), rules), ErrTooManyTrails)
+
+	// Short enough for the trails to be counted.
+	board = readBoard(t, strings.Repeat(strings.Join(row[:40], " ")+"\n", 3), encoding)
+	require.NoError(t, board.CountTrails(context.Background(), rules))
+	total, err := board.TotalTrails()
+	require.NoError(t, err)
+	assert.Greater(t, total, 1<<50)
+}
@@ -0,0 +1,188 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"strings"
+
+	"common/command"
+	"common/report"
+	"dayten/b/lib"
+
+	"github.com/samber/lo"
+)
+
+type Args struct {
+	command.Flags
+
+	Climbs             []int    `arg:"--climbs,separate"   help:"elevation gain allowed in a single step, above zero (repeatable; default: 1)"`
+	Diagonal           bool     `arg:"--diagonal"          help:"allow diagonal steps"`
+	Trailhead          string   `arg:"--trailhead"         help:"only export trails from this trailhead, given as row,col"`
+	Export             string   `arg:"--export"            help:"write the trails from every trailhead to this JSON file"`
+	Limit              int      `arg:"--limit"             default:"1000" help:"most trails exported per trailhead; trails are sampled beyond this"`
+	Seed               uint64   `arg:"--seed"              default:"1"    help:"seed for sampling trails"`
+	HeatMap            bool     `arg:"--heatmap"           help:"render how many trails pass through each cell"`
+	Encoding           string   `arg:"--encoding"             default:"digits" help:"map encoding: digits, letters or fields"`
+	Impassable         []string `arg:"--impassable,separate"  help:"marker of impassable cells (repeatable; default: .)"`
+	TrailheadElevation *int     `arg:"--trailhead-elevation"  help:"elevation of trailheads"`
+	PeakElevation      *int     `arg:"--peak-elevation"       help:"elevation of peaks"`
+}
+
+// TrailheadTrails lists the trails from one trailhead, as [row, col] pairs; Sampled is set when the trails were drawn
+// at random because there were more than the export limit.
+type TrailheadTrails struct {
+	Trailhead [2]int     `json:"trailhead"`
+	Count     int        `json:"count"`
+	Sampled   bool       `json:"sampled"`
+	Trails    [][][2]int `json:"trails"`
+}
+
+const heatMapShades = " .:-=+*#%@"
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	board, err := readInput(args, input, out)
+	if err != nil {
+		return err
+	}
+	if len(board.Grid) < 1 {
+		return errors.New("grid is empty")
+	}
+
+	rules := lib.DefaultStepRules()
+	if len(args.Climbs) > 0 {
+		rules.Climbs = args.Climbs
+	}
+	rules.Diagonal = args.Diagonal
+
+	dimensions := board.Dimensions()
+	err = board.CountTrails(ctx, rules)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+	totalScore, err := board.TotalTrails()
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	slog.Debug("read grid", "dimensions", dimensions)
+	slog.Info("solved", "totalScore", totalScore)
+	out.Parsed("rows", dimensions.Row)
+	out.Parsed("cols", dimensions.Col)
+	out.Answer("totalScore", totalScore)
+
+	if args.Export != "" {
+		trailheads := board.ByElevation[board.Trailhead]
+		if args.Trailhead != "" {
+			var trailhead lib.Coord
+			_, err := fmt.Sscanf(args.Trailhead, "%d,%d", &trailhead.Row, &trailhead.Col)
+			if err != nil || !trailhead.IsValid(dimensions) || board.Grid[trailhead.Row][trailhead.Col].Elevation != board.Trailhead {
+				return fmt.Errorf("invalid trailhead: %s", args.Trailhead)
+			}
+			trailheads = []lib.Coord{trailhead}
+		}
+
+		err := exportTrails(ctx, args.Export, &board, trailheads, rules, args.Limit, args.Seed)
+		if err != nil {
+			return err
+		}
+	}
+
+	if args.HeatMap {
+		heatMap, err := board.HeatMap(ctx, rules)
+		if err != nil {
+			return err //nolint:wrapcheck // Toy code
+		}
+		logHeatMap(heatMap)
+	}
+
+	return nil
+}
+
+func exportTrails(
+	ctx context.Context, path string, board *lib.Board, trailheads []lib.Coord, rules lib.StepRules, limit int, seed uint64,
+) (err error) {
+	rng := rand.New(rand.NewPCG(seed, seed)) //nolint:gosec // Not meant to be secure
+	toPairs := func(trail []lib.Coord, _ int) [][2]int {
+		return lo.Map(trail, func(coord lib.Coord, _ int) [2]int {
+			return [2]int{coord.Row, coord.Col}
+		})
+	}
+
+	allTrails := make([]TrailheadTrails, 0, len(trailheads))
+	for _, trailhead := range trailheads {
+		err = ctx.Err()
+		if err != nil {
+			return err //nolint:wrapcheck // Toy code
+		}
+
+		trailheadTrails := TrailheadTrails{
+			Trailhead: [2]int{trailhead.Row, trailhead.Col},
+			Count:     board.Grid[trailhead.Row][trailhead.Col].TrailCount,
+		}
+		if trailheadTrails.Count > limit {
+			trailheadTrails.Sampled = true
+			for range limit {
+				trailheadTrails.Trails = append(trailheadTrails.Trails, toPairs(board.SampleTrail(trailhead, rules, rng), 0))
+			}
+		} else {
+			trailheadTrails.Trails = lo.Map(board.Trails(trailhead, rules, limit), toPairs)
+		}
+		allTrails = append(allTrails, trailheadTrails)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	encoder := json.NewEncoder(file)
+
+	return encoder.Encode(allTrails) //nolint:wrapcheck // Toy code
+}
+
+// logHeatMap renders the heat map with one shade per cell, from blank for cells no trail passes through to `@` for the
+// busiest cell.
+func logHeatMap(heatMap [][]int) {
+	maxHeat := lo.Max(lo.Map(heatMap, func(row []int, _ int) int {
+		return lo.Max(row)
+	}))
+	slog.Info("heat map", "busiestCell", maxHeat)
+	for _, row := range heatMap {
+		var sb strings.Builder
+		for _, heat := range row {
+			iShade := 0
+			if heat > 0 {
+				iShade = 1 + (heat-1)*(len(heatMapShades)-2)/max(maxHeat-1, 1)
+			}
+			sb.WriteByte(heatMapShades[iShade])
+		}
+		fmt.Fprintln(os.Stderr, sb.String())
+	}
+}
+
+func readInput(args Args, input io.Reader, out *report.Report) (lib.Board, error) {
+	encoding := lib.DefaultEncoding()
+	encoding.Name = args.Encoding
+	if len(args.Impassable) > 0 {
+		encoding.Impassable = args.Impassable
+	}
+	encoding.Trailhead = args.TrailheadElevation
+	encoding.Peak = args.PeakElevation
+
+	scanner := bufio.NewScanner(out.CountInput(input))
+	board, err := lib.ReadInput(scanner, encoding)
+
+	return board, err //nolint:wrapcheck // Toy code
+}
@@ -0,0 +1,192 @@
+package lib
+
+import (
+	"bufio"
+	"container/list"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"common/parsing"
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readRules(t *testing.T, text string) []Rule {
+	t.Helper()
+	rules, err := ReadRules(bufio.NewScanner(strings.NewReader(text)))
+	require.NoError(t, err)
+	return rules
+}
+
+// apply blinks once at a stone, returning the new stones as strings.
+func apply(t *testing.T, rules []Rule, value int64) []string {
+	t.Helper()
+	newValues, err := ApplyRules(rules, big.NewInt(value))
+	require.NoError(t, err)
+	stones := make([]string, len(newValues))
+	for iValue, newValue := range newValues {
+		stones[iValue] = newValue.String()
+	}
+	return stones
+}
+
+// printStones writes the stones back out in the puzzle format.
+func printStones(stones *list.List) string {
+	var values []string
+	for element := stones.Front(); element != nil; element = element.Next() {
+		values = append(values, element.Value.(*big.Int).String())
+	}
+	return strings.Join(values, " ") + "\n"
+}
+
+func TestReadRules(t *testing.T) {
+	rules := readRules(t, "# Comment\n\n  value == 7 -> add 3\ndigits % 3 == 0 -> split 3\n  always  ->  multiply 2\n")
+	require.Len(t, rules, 3)
+	assert.Equal(t, "value == 7 -> add 3", rules[0].Text)
+	assert.Equal(t, []string{"10"}, apply(t, rules, 7))
+	assert.Equal(t, []string{"12", "34", "56"}, apply(t, rules, 123456))
+	assert.Equal(t, []string{"12"}, apply(t, rules, 6))
+
+	// Rules apply in order, the first one holding winning.
+	rules = readRules(t, "always -> set 5\nvalue == 0 -> set 1\n")
+	assert.Equal(t, []string{"5"}, apply(t, rules, 0))
+}
+
+func TestReadRulesErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		col   int
+		text  string
+		err   string
+	}{
+		{input: "always set 1", col: 1, text: "always set 1", err: "missing `->` in rule"},
+		{input: "  sometimes -> set 1", col: 3, text: "sometimes", err: "unrecognized predicate"},
+		{input: "value == x -> set 1", col: 1, text: "value == x", err: "invalid value in predicate: `x`"},
+		{input: "digits % 0 == 0 -> set 1", col: 1, text: "digits % 0 == 0", err: "invalid modulus in predicate: `0`"},
+		{input: "always ->  divide 2", col: 12, text: "divide 2", err: "unrecognized transform"},
+		{input: "always -> split 0", col: 11, text: "split 0", err: "invalid number of parts in transform: `0`"},
+		{input: "always -> set", col: 11, text: "set", err: "unrecognized transform"},
+		{input: "always -> add two", col: 11, text: "add two", err: "invalid operand in transform: `two`"},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			_, err := ReadRules(bufio.NewScanner(strings.NewReader("always -> set 1\n" + test.input + "\n")))
+			var parseErr *parsing.Error
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, 2, parseErr.Line)
+			assert.Equal(t, test.col, parseErr.Col)
+			assert.Equal(t, test.text, parseErr.Text)
+			assert.EqualError(t, parseErr.Err, test.err)
+		})
+	}
+
+	_, err := ReadRules(bufio.NewScanner(strings.NewReader("# Nothing\n")))
+	assert.EqualError(t, err, "no rules found")
+}
+
+func TestApplyRules(t *testing.T) {
+	rules := readRules(t, DefaultRules)
+	tests := []struct {
+		value  int64
+		stones []string
+	}{
+		{value: 0, stones: []string{"1"}},
+		{value: 1, stones: []string{"2024"}},
+		{value: 10, stones: []string{"1", "0"}},
+		{value: 99, stones: []string{"9", "9"}},
+		{value: 999, stones: []string{"2021976"}},
+		{value: 1000, stones: []string{"10", "0"}},
+		{value: 253000, stones: []string{"253", "0"}},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.stones, apply(t, rules, test.value), test.value)
+	}
+}
+
+func TestApplyRulesErrors(t *testing.T) {
+	rules := readRules(t, "value == 1 -> add -2\nvalue == 2 -> multiply -1\nvalue == 3 -> set -1\nvalue == 4 -> add -4\nalways -> split 2\n")
+	for _, value := range []int64{1, 2, 3} {
+		_, err := ApplyRules(rules, big.NewInt(value))
+		assert.ErrorContains(t, err, "gives negative stone", value)
+	}
+	// Zero is fine.
+	assert.Equal(t, []string{"0"}, apply(t, rules, 4))
+
+	_, err := ApplyRules(rules, big.NewInt(123))
+	assert.EqualError(t, err, "rule `always -> split 2` on stone 123: cannot split 3 digits into 2 equal parts")
+
+	_, err = ApplyRules(readRules(t, "value == 0 -> set 1\n"), big.NewInt(5))
+	assert.EqualError(t, err, "no rule matches stone 5")
+}
+
+func TestReadInputNegative(t *testing.T) {
+	_, err := ReadInput(bufio.NewScanner(strings.NewReader("125 -17\n")))
+	var parseErr *parsing.Error
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, 5, parseErr.Col)
+	assert.Equal(t, "-17", parseErr.Text)
+}
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("125 17\n")
+	f.Add("  0\t1 10\n\n99 999\n")
+	f.Add("125 -17\n")
+	f.Add("125 x\n")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, input string) {
+		stones, err := ReadInput(bufio.NewScanner(strings.NewReader(input)))
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+
+		printed := printStones(stones)
+		reread, err := ReadInput(bufio.NewScanner(strings.NewReader(printed)))
+		require.NoError(t, err)
+		assert.Equal(t, printed, printStones(reread))
+	})
+}
+
+func FuzzReadRules(f *testing.F) {
+	f.Add(DefaultRules)
+	f.Add("# Comment\n\n  value == 7 -> add 3\ndigits % 3 == 0 -> split 3\n  always  ->  multiply 2\n")
+	f.Add("digits % 2 == 1 -> add -5\nalways -> split 2\n")
+	f.Add("always set 1\n")
+	f.Add("value == x -> set 1\n")
+	f.Add("always -> split 0\n")
+	f.Add("# Nothing\n")
+	f.Fuzz(func(t *testing.T, input string) {
+		rules, err := ReadRules(bufio.NewScanner(strings.NewReader(input)))
+		if errors.Is(err, ErrNoRules) {
+			return
+		}
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+
+		texts := make([]string, len(rules))
+		for iRule, rule := range rules {
+			texts[iRule] = rule.Text
+		}
+		reread := readRules(t, strings.Join(texts, "\n"))
+		require.Len(t, reread, len(rules))
+		for iRule, rule := range reread {
+			assert.Equal(t, texts[iRule], rule.Text)
+		}
+
+		// Applying the rules either gives stones that are not negative or fails.
+		for _, value := range []int64{0, 1, 7, 10, 123456, 2024} {
+			newValues, err := ApplyRules(rules, big.NewInt(value))
+			if err == nil {
+				for _, newValue := range newValues {
+					assert.GreaterOrEqual(t, newValue.Sign(), 0)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,111 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"math/big"
+	"os"
+	"strings"
+
+	"common/command"
+	"common/parsing"
+	"common/report"
+	"dayeleven/a/lib"
+)
+
+// cancelCheckInterval is the number of stones blinked at between checks for cancellation, as a single blink may take
+// long once the stones have multiplied.
+const cancelCheckInterval = 1 << 16
+
+type Args struct {
+	command.Flags
+
+	NumSteps  int    `arg:"-n"                  default:"25"      help:"number of steps to take"`
+	RulesFile string `arg:"-r,--rules"          help:"stone rules file"`
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	if args.NumSteps < 1 {
+		return fmt.Errorf("number of steps must be at least 1; got %d", args.NumSteps)
+	}
+
+	rules, err := readRulesFile(args)
+	if err != nil {
+		rulesFile := args.RulesFile
+		if rulesFile == "" {
+			rulesFile = "<default rules>"
+		}
+		return parsing.InFile(rulesFile, err)
+	}
+
+	theList, err := readInput(input, out)
+	if err != nil {
+		return err
+	}
+
+	for iStep := range args.NumSteps {
+		slog.Debug("step", "step", iStep+1, "length", theList.Len())
+		iStone := 0
+		for link := theList.Front(); link != nil; link = link.Next() {
+			if iStone%cancelCheckInterval == 0 {
+				err = ctx.Err()
+				if err != nil {
+					return err //nolint:wrapcheck // Toy code
+				}
+			}
+			iStone++
+
+			val, ok := link.Value.(*big.Int)
+			if !ok {
+				log.Panicf("expected value to be of type big.Int; got %T", link.Value)
+			}
+
+			newVals, err := lib.ApplyRules(rules, val)
+			if err != nil {
+				return err //nolint:wrapcheck // Toy code
+			}
+
+			link.Value = newVals[0]
+			for _, newVal := range newVals[1:] {
+				theList.InsertAfter(newVal, link)
+				link = link.Next()
+			}
+		}
+	}
+
+	slog.Info("solved", "stones", theList.Len())
+	out.Answer("stones", theList.Len())
+
+	return nil
+}
+
+func readRulesFile(args Args) (rules []lib.Rule, err error) {
+	if args.RulesFile == "" {
+		return lib.ReadRules(bufio.NewScanner(strings.NewReader(lib.DefaultRules))) //nolint:wrapcheck // Toy code
+	}
+
+	file, err := os.Open(args.RulesFile)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	return lib.ReadRules(bufio.NewScanner(file)) //nolint:wrapcheck // Toy code
+}
+
+func readInput(input io.Reader, out *report.Report) (*list.List, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	theList, err := lib.ReadInput(scanner)
+
+	return theList, err //nolint:wrapcheck // Toy code
+}
@@ -2,23 +2,230 @@ package lib
 
 import (
 	"bufio"
+	"errors"
+	"fmt"
 	"math/big"
+	"strconv"
 	"strings"
+	"unicode"
+
+	"common/parsing"
 )
 
-func ReadInput(scanner *bufio.Scanner) []*big.Int {
+func ReadInput(scanner *bufio.Scanner) ([]*big.Int, error) {
 	values := make([]*big.Int, 0)
+	lineNum := 0
 	for scanner.Scan() {
+		lineNum++
 		line := scanner.Text()
-		for _, str := range strings.Fields(line) {
+		fields, cols := fieldColumns(line)
+		for iField, str := range fields {
 			var value big.Int
 			_, success := value.SetString(str, 10) //nolint:mnd // false positive
-			if !success {
-				continue
+			if !success || value.Sign() < 0 {
+				return nil, parsing.Errorf(lineNum, cols[iField], str, "invalid stone")
 			}
 			values = append(values, &value)
 		}
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+
+	return values, nil
+}
+
+// fieldColumns splits the line around runs of white space, like strings.Fields, also returning the 1-based column at
+// which every field starts.
+func fieldColumns(line string) ([]string, []int) {
+	var fields []string
+	var cols []int
+	start := -1
+	for iByte, char := range line + " " {
+		switch {
+		case unicode.IsSpace(char) && start >= 0:
+			fields = append(fields, line[start:iByte])
+			cols = append(cols, start+1)
+			start = -1
+		case !unicode.IsSpace(char) && start < 0:
+			start = iByte
+		}
+	}
+	return fields, cols
+}
+
+const BaseTen = 10
+
+// ErrNoRules is returned by ReadRules when there is not a single rule to read.
+var ErrNoRules = errors.New("no rules found")
+
+// DefaultRules are the rules of the puzzle, in the format read by ReadRules.
+const DefaultRules = `
+value == 0 -> set 1
+digits % 2 == 0 -> split 2
+always -> multiply 2024
+`
+
+type Predicate func(value *big.Int, str string) bool
+
+type Transform func(value *big.Int, str string) ([]*big.Int, error)
+
+// Rule transforms a stone into one or more stones when its predicate holds; rules are tried in order and the first
+// one whose predicate holds is applied.
+type Rule struct {
+	Text      string
+	Predicate Predicate
+	Transform Transform
+}
+
+// ReadRules reads one rule per line, as `<predicate> -> <transform>`. The predicates are `always`, `value == N` and
+// `digits % K == R`, and the transforms are `set N`, `add N`, `multiply N` and `split K`, which cuts the digits into K
+// equally long parts. Blank lines and lines starting with `#` are ignored. Stones are never negative, as the number of
+// digits would then count the sign: applying a transform that gives a negative stone is an error.
+func ReadRules(scanner *bufio.Scanner) ([]Rule, error) {
+	var rules []Rule
+	iLine := 0
+	for scanner.Scan() {
+		line := scanner.Text()
+		iLine++
+		trimmed := strings.TrimSpace(line)
+		if len(trimmed) < 1 || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		predicateText, transformText, found := strings.Cut(line, "->")
+		if !found {
+			return nil, parsing.Errorf(iLine, strings.Index(line, trimmed)+1, trimmed, "missing `->` in rule")
+		}
+
+		predicate, err := parsePredicate(strings.Fields(predicateText))
+		if err != nil {
+			return nil, &parsing.Error{Line: iLine, Col: textColumn(predicateText, 0), Text: strings.TrimSpace(predicateText), Err: err}
+		}
+
+		transform, err := parseTransform(strings.Fields(transformText))
+		if err != nil {
+			transformStart := len(predicateText) + len("->")
+			return nil, &parsing.Error{Line: iLine, Col: textColumn(transformText, transformStart), Text: strings.TrimSpace(transformText), Err: err}
+		}
+
+		rules = append(rules, Rule{Text: trimmed, Predicate: predicate, Transform: transform})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+
+	if len(rules) < 1 {
+		return nil, ErrNoRules
+	}
+
+	return rules, nil
+}
+
+// textColumn returns the 1-based column of the first non-blank character of text, which starts at the given offset
+// into its line.
+func textColumn(text string, offset int) int {
+	return offset + len(text) - len(strings.TrimLeftFunc(text, unicode.IsSpace)) + 1
+}
+
+// ApplyRules returns the stones that the value turns into after one blink.
+func ApplyRules(rules []Rule, value *big.Int) ([]*big.Int, error) {
+	str := value.String()
+	for _, rule := range rules {
+		if !rule.Predicate(value, str) {
+			continue
+		}
+
+		newValues, err := rule.Transform(value, str)
+		if err != nil {
+			return nil, fmt.Errorf("rule `%s` on stone %s: %w", rule.Text, str, err)
+		}
+		for _, newValue := range newValues {
+			if newValue.Sign() < 0 {
+				return nil, fmt.Errorf("rule `%s` on stone %s: gives negative stone %s", rule.Text, str, newValue)
+			}
+		}
+
+		return newValues, nil
+	}
+
+	return nil, fmt.Errorf("no rule matches stone %s", str)
+}
+
+func parsePredicate(fields []string) (Predicate, error) {
+	switch {
+	case len(fields) == 1 && fields[0] == "always":
+		return func(*big.Int, string) bool {
+			return true
+		}, nil
+	case len(fields) == 3 && fields[0] == "value" && fields[1] == "==":
+		var target big.Int
+		if _, success := target.SetString(fields[2], BaseTen); !success {
+			return nil, fmt.Errorf("invalid value in predicate: `%s`", fields[2])
+		}
+		return func(value *big.Int, _ string) bool {
+			return value.Cmp(&target) == 0
+		}, nil
+	case len(fields) == 5 && fields[0] == "digits" && fields[1] == "%" && fields[3] == "==":
+		modulus, err := strconv.Atoi(fields[2])
+		if err != nil || modulus < 1 {
+			return nil, fmt.Errorf("invalid modulus in predicate: `%s`", fields[2])
+		}
+		remainder, err := strconv.Atoi(fields[4])
+		if err != nil {
+			return nil, fmt.Errorf("invalid remainder in predicate: `%s`", fields[4])
+		}
+		return func(_ *big.Int, str string) bool {
+			return len(str)%modulus == remainder
+		}, nil
+	}
+
+	return nil, errors.New("unrecognized predicate")
+}
+
+func parseTransform(fields []string) (Transform, error) {
+	if len(fields) != 2 { //nolint:mnd // Transforms take exactly one argument
+		return nil, errors.New("unrecognized transform")
+	}
+
+	if fields[0] == "split" {
+		nParts, err := strconv.Atoi(fields[1])
+		if err != nil || nParts < 1 {
+			return nil, fmt.Errorf("invalid number of parts in transform: `%s`", fields[1])
+		}
+		return func(_ *big.Int, str string) ([]*big.Int, error) {
+			if len(str)%nParts != 0 {
+				return nil, fmt.Errorf("cannot split %d digits into %d equal parts", len(str), nParts)
+			}
+			partLen := len(str) / nParts
+			newValues := make([]*big.Int, nParts)
+			for iPart := range nParts {
+				newValues[iPart], _ = new(big.Int).SetString(str[iPart*partLen:(iPart+1)*partLen], BaseTen)
+			}
+			return newValues, nil
+		}, nil
+	}
+
+	var operand big.Int
+	if _, success := operand.SetString(fields[1], BaseTen); !success {
+		return nil, fmt.Errorf("invalid operand in transform: `%s`", fields[1])
+	}
+
+	switch fields[0] {
+	case "set":
+		return func(*big.Int, string) ([]*big.Int, error) {
+			return []*big.Int{new(big.Int).Set(&operand)}, nil
+		}, nil
+	case "add":
+		return func(value *big.Int, _ string) ([]*big.Int, error) {
+			return []*big.Int{new(big.Int).Add(value, &operand)}, nil
+		}, nil
+	case "multiply":
+		return func(value *big.Int, _ string) ([]*big.Int, error) {
+			return []*big.Int{new(big.Int).Mul(value, &operand)}, nil
+		}, nil
+	}
 
-	return values
+	return nil, errors.New("unrecognized transform")
 }
@@ -0,0 +1,422 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"math/bits"
+	"os"
+	"slices"
+	"strings"
+
+	"common/command"
+	"common/logging"
+	"common/parsing"
+	"common/report"
+	"dayeleven/b/lib"
+
+	"github.com/samber/lo"
+)
+
+var One = big.NewInt(1) //nolint:gochecknoglobals,mnd // Meant as a constant.
+
+type LaunchPoint struct {
+	Str            string
+	RemainingDepth int
+}
+
+type Args struct {
+	command.Flags
+
+	NumSteps  int    `arg:"-n"                  default:"25"      help:"number of steps to take"`
+	RulesFile string `arg:"-r,--rules"          help:"stone rules file"`
+	Engine    string `arg:"-e,--engine"         default:"cache"   help:"counting engine: cache or multiset"`
+	Histogram bool   `arg:"--histogram"         help:"log the full value histogram at every step (multiset engine, which then never raises its transition matrix to a power)"`
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	if args.NumSteps < 1 {
+		return fmt.Errorf("number of steps must be at least 1; got %d", args.NumSteps)
+	}
+
+	rules, err := readRulesFile(args)
+	if err != nil {
+		rulesFile := args.RulesFile
+		if rulesFile == "" {
+			rulesFile = "<default rules>"
+		}
+		return parsing.InFile(rulesFile, err)
+	}
+
+	values, err := readInput(input, out)
+	if err != nil {
+		return err
+	}
+	var total *big.Int
+	switch args.Engine {
+	case "cache":
+		total = big.NewInt(0)
+		expansionCache := make(map[LaunchPoint]*big.Int)
+		for _, value := range values {
+			count, err := expandWithCache(ctx, rules, value, args.NumSteps, &expansionCache)
+			if err != nil {
+				return err
+			}
+			total.Add(total, count)
+		}
+	case "multiset":
+		total, err = countWithMultiset(ctx, rules, values, args.NumSteps, args.Histogram)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unrecognized engine: %s", args.Engine)
+	}
+
+	slog.Info("solved", "stones", total)
+	out.Parsed("values", len(values))
+	out.Answer("stones", total)
+
+	return nil
+}
+
+func readRulesFile(args Args) (rules []lib.Rule, err error) {
+	if args.RulesFile == "" {
+		return lib.ReadRules(bufio.NewScanner(strings.NewReader(lib.DefaultRules))) //nolint:wrapcheck // Toy code
+	}
+
+	file, err := os.Open(args.RulesFile)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	return lib.ReadRules(bufio.NewScanner(file)) //nolint:wrapcheck // Toy code
+}
+
+func readInput(input io.Reader, out *report.Report) ([]*big.Int, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	values, err := lib.ReadInput(scanner)
+
+	return values, err //nolint:wrapcheck // Toy code
+}
+
+func expandWithCache(
+	ctx context.Context, rules []lib.Rule, value *big.Int, numSteps int, expansionCache *map[LaunchPoint]*big.Int,
+) (*big.Int, error) {
+	str := value.String()
+	if total, ok := (*expansionCache)[LaunchPoint{Str: str, RemainingDepth: numSteps}]; ok {
+		return total, nil
+	}
+
+	total, err := expand(ctx, rules, value, numSteps, expansionCache)
+	if err != nil {
+		return nil, err
+	}
+	(*expansionCache)[LaunchPoint{Str: str, RemainingDepth: numSteps}] = total
+
+	return total, nil
+}
+
+func expand(
+	ctx context.Context, rules []lib.Rule, value *big.Int, numSteps int, expansionCache *map[LaunchPoint]*big.Int,
+) (*big.Int, error) {
+	if numSteps < 1 {
+		return One, nil
+	}
+	err := ctx.Err()
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+
+	newValues, err := lib.ApplyRules(rules, value)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+
+	total := big.NewInt(0)
+	for _, newValue := range newValues {
+		count, err := expandWithCache(ctx, rules, newValue, numSteps-1, expansionCache)
+		if err != nil {
+			return nil, err
+		}
+		total.Add(total, count)
+	}
+
+	return total, nil
+}
+
+// countWithMultiset tracks how many stones carry each value, one blink at a time. Once a blink brings no value that has
+// not been seen before, the seen values are closed under the rules, and the remaining blinks are computed with the
+// transition matrix over those values.
+func countWithMultiset(
+	ctx context.Context, rules []lib.Rule, values []*big.Int, numSteps int, showHistogram bool,
+) (*big.Int, error) {
+	counts := make(map[string]*big.Int)
+	for _, value := range values {
+		addCount(counts, value.String(), One)
+	}
+
+	transitions := make(map[string][]string)
+	for iStep := range numSteps {
+		err := ctx.Err()
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Toy code
+		}
+
+		nextCounts := make(map[string]*big.Int)
+		for str, count := range counts {
+			children, ok := transitions[str]
+			if !ok {
+				value, _ := new(big.Int).SetString(str, lib.BaseTen)
+				newValues, err := lib.ApplyRules(rules, value)
+				if err != nil {
+					return nil, err //nolint:wrapcheck // Toy code
+				}
+				children = make([]string, len(newValues))
+				for iChild, newValue := range newValues {
+					children[iChild] = newValue.String()
+				}
+				transitions[str] = children
+			}
+
+			for _, child := range children {
+				addCount(nextCounts, child, count)
+			}
+		}
+		counts = nextCounts
+		logHistogram(iStep+1, counts, showHistogram)
+
+		isClosed := true
+		for str := range counts {
+			if _, ok := transitions[str]; !ok {
+				isClosed = false
+				break
+			}
+		}
+		if isClosed && iStep+1 < numSteps {
+			slog.Debug("distinct values are closed under the rules", "step", iStep+1, "values", len(transitions))
+			return countWithMatrix(ctx, transitions, counts, numSteps-iStep-1, iStep+1, showHistogram)
+		}
+	}
+
+	return sumCounts(counts), nil
+}
+
+// countWithMatrix advances the counts by the remaining steps with the transition matrix over the closed set of values,
+// either by raising the matrix to the power of the remaining steps or by applying it once per step, whichever takes
+// fewer multiplications. Raising the matrix to a power skips the counts of the steps in between, so the matrix is
+// always applied once per step when the histogram of every step is asked for.
+func countWithMatrix(
+	ctx context.Context, transitions map[string][]string, counts map[string]*big.Int, numSteps, iStep int,
+	showHistogram bool,
+) (*big.Int, error) {
+	strs := lo.Keys(transitions)
+	slices.Sort(strs)
+	indexByStr := make(map[string]int, len(strs))
+	for iStr, str := range strs {
+		indexByStr[str] = iStr
+	}
+
+	vector := make([]*big.Int, len(strs))
+	for iStr, str := range strs {
+		vector[iStr] = new(big.Int)
+		if count, ok := counts[str]; ok {
+			vector[iStr].Set(count)
+		}
+	}
+
+	sparse := make([][]int, len(strs))
+	nNonZero := 0
+	for iStr, str := range strs {
+		sparse[iStr] = lo.Map(transitions[str], func(child string, _ int) int {
+			return indexByStr[child]
+		})
+		nNonZero += len(sparse[iStr])
+	}
+
+	squaringCost := big.NewInt(int64(len(strs)))
+	squaringCost.Exp(squaringCost, big.NewInt(3), nil).Mul(squaringCost, big.NewInt(int64(2*bits.Len(uint(numSteps))))) //nolint:mnd // Two cubic products per bit
+	steppingCost := big.NewInt(int64(nNonZero))
+	steppingCost.Mul(steppingCost, big.NewInt(int64(numSteps)))
+	if squaringCost.Cmp(steppingCost) < 0 && !showHistogram {
+		slog.Debug("raising transition matrix to a power", "size", len(strs), "power", numSteps)
+		vector = transitionMatrix(sparse).pow(numSteps).apply(vector)
+		return sumSlice(vector), nil
+	}
+
+	slog.Debug("applying transition matrix", "size", len(strs), "times", numSteps)
+	for jStep := range numSteps {
+		err := ctx.Err()
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Toy code
+		}
+
+		vector = stepVector(sparse, vector)
+
+		if showHistogram {
+			nextCounts := make(map[string]*big.Int)
+			for iStr, count := range vector {
+				if count.Sign() != 0 {
+					nextCounts[strs[iStr]] = count
+				}
+			}
+			logHistogram(iStep+jStep+1, nextCounts, showHistogram)
+		}
+	}
+
+	return sumSlice(vector), nil
+}
+
+// stepVector returns the counts after one step, given the children of every value as indices.
+func stepVector(sparse [][]int, vector []*big.Int) []*big.Int {
+	nextVector := make([]*big.Int, len(vector))
+	for iStr := range nextVector {
+		nextVector[iStr] = new(big.Int)
+	}
+	for iStr, count := range vector {
+		if count.Sign() == 0 {
+			continue
+		}
+		for _, iChild := range sparse[iStr] {
+			nextVector[iChild].Add(nextVector[iChild], count)
+		}
+	}
+
+	return nextVector
+}
+
+// matrix holds how many stones of the column's value each stone of the row's value turns into.
+type matrix [][]*big.Int
+
+func transitionMatrix(sparse [][]int) matrix {
+	m := zeroMatrix(len(sparse))
+	for iRow, children := range sparse {
+		for _, iChild := range children {
+			m[iRow][iChild].Add(m[iRow][iChild], One)
+		}
+	}
+
+	return m
+}
+
+func zeroMatrix(size int) matrix {
+	m := make(matrix, size)
+	for iRow := range m {
+		m[iRow] = make([]*big.Int, size)
+		for iCol := range m[iRow] {
+			m[iRow][iCol] = new(big.Int)
+		}
+	}
+
+	return m
+}
+
+func (m matrix) mul(other matrix) matrix {
+	product := zeroMatrix(len(m))
+	var term big.Int
+	for iRow := range m {
+		for k, left := range m[iRow] {
+			if left.Sign() == 0 {
+				continue
+			}
+			for iCol, right := range other[k] {
+				if right.Sign() == 0 {
+					continue
+				}
+				product[iRow][iCol].Add(product[iRow][iCol], term.Mul(left, right))
+			}
+		}
+	}
+
+	return product
+}
+
+func (m matrix) pow(exponent int) matrix {
+	result := zeroMatrix(len(m))
+	for i := range result {
+		result[i][i].Set(One)
+	}
+
+	base := m
+	for exponent > 0 {
+		if exponent%2 == 1 {
+			result = result.mul(base)
+		}
+		exponent /= 2
+		if exponent > 0 {
+			base = base.mul(base)
+		}
+	}
+
+	return result
+}
+
+func (m matrix) apply(vector []*big.Int) []*big.Int {
+	result := make([]*big.Int, len(vector))
+	for iCol := range result {
+		result[iCol] = new(big.Int)
+	}
+
+	var term big.Int
+	for iRow, count := range vector {
+		if count.Sign() == 0 {
+			continue
+		}
+		for iCol, entry := range m[iRow] {
+			result[iCol].Add(result[iCol], term.Mul(count, entry))
+		}
+	}
+
+	return result
+}
+
+func addCount(counts map[string]*big.Int, str string, count *big.Int) {
+	if _, ok := counts[str]; !ok {
+		counts[str] = new(big.Int)
+	}
+	counts[str].Add(counts[str], count)
+}
+
+func sumCounts(counts map[string]*big.Int) *big.Int {
+	return sumSlice(lo.Values(counts))
+}
+
+func sumSlice(counts []*big.Int) *big.Int {
+	total := big.NewInt(0)
+	for _, count := range counts {
+		total.Add(total, count)
+	}
+
+	return total
+}
+
+// logHistogram logs the step's stone count at debug level, as summing the counts is not free, or along with the full
+// histogram when asked for.
+func logHistogram(iStep int, counts map[string]*big.Int, showHistogram bool) {
+	if !showHistogram {
+		if logging.DebugEnabled() {
+			slog.Debug("step", "step", iStep, "distinctValues", len(counts), "stones", sumCounts(counts))
+		}
+		return
+	}
+
+	slog.Info("step", "step", iStep, "distinctValues", len(counts), "stones", sumCounts(counts))
+
+	strs := lo.Keys(counts)
+	slices.SortFunc(strs, func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(a), len(b)), cmp.Compare(a, b))
+	})
+	for _, str := range strs {
+		slog.Info("histogram", "step", iStep, "value", str, "stones", counts[str])
+	}
+}
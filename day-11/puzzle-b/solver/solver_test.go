@@ -0,0 +1,98 @@
+package solver
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	"common/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// discardLogs keeps the histograms out of the test output.
+func discardLogs(t *testing.T) {
+	t.Helper()
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+}
+
+func TestEngines(t *testing.T) {
+	discardLogs(t)
+	committed, err := os.ReadFile("../../input/input.txt")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		input    string
+		numSteps int
+		stones   string
+	}{
+		{name: "example", input: "125 17\n", numSteps: 25, stones: "55312"},
+		{name: "committed input", input: string(committed), numSteps: 75, stones: "218817038947400"},
+		// A single zero is closed under the rules after 17 steps, so the multiset engine goes on with the matrix.
+		{name: "zero", input: "0\n", numSteps: 75, stones: "22938365706844"},
+	}
+	engines := []Args{
+		{Engine: "cache"},
+		{Engine: "multiset"},
+		{Engine: "multiset", Histogram: true},
+	}
+	for _, test := range tests {
+		for _, args := range engines {
+			args.NumSteps = test.numSteps
+			out := report.New(report.JSON)
+			require.NoError(t, Solve(context.Background(), args, strings.NewReader(test.input), out))
+			assert.Equal(t, test.stones, out.Answers()["stones"].(*big.Int).String(), "%s with %+v", test.name, args)
+		}
+	}
+}
+
+func TestMatrixPow(t *testing.T) {
+	// 0 turns into 1, 1 into 0 and 2, and 2 into two 2s.
+	sparse := [][]int{{1}, {0, 2}, {2, 2}}
+	start := []*big.Int{big.NewInt(3), big.NewInt(1), big.NewInt(0)}
+
+	vector := start
+	for numSteps := range 20 {
+		assert.Equal(t, strs(vector), strs(transitionMatrix(sparse).pow(numSteps).apply(start)), "%d steps", numSteps)
+		vector = stepVector(sparse, vector)
+	}
+}
+
+func TestCountWithMatrix(t *testing.T) {
+	discardLogs(t)
+	transitions := map[string][]string{"0": {"1"}, "1": {"0", "2"}, "2": {"2", "2"}}
+	counts := map[string]*big.Int{"0": big.NewInt(3), "1": big.NewInt(1)}
+
+	// Raising the matrix to a power is cheaper for that many steps, but the histograms need every step.
+	ctx := context.Background()
+	raised, err := countWithMatrix(ctx, transitions, counts, 1000, 0, false)
+	require.NoError(t, err)
+	stepped, err := countWithMatrix(ctx, transitions, counts, 1000, 0, true)
+	require.NoError(t, err)
+	assert.Equal(t, stepped.String(), raised.String())
+	total, err := countWithMatrix(ctx, transitions, counts, 2, 0, false)
+	require.NoError(t, err)
+	assert.Equal(t, "9", total.String())
+
+	// Stepping stops once cancelled.
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = countWithMatrix(cancelled, transitions, counts, 1000, 0, true)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func strs(vector []*big.Int) []string {
+	result := make([]string, len(vector))
+	for i, count := range vector {
+		result[i] = count.String()
+	}
+	return result
+}
@@ -2,6 +2,9 @@ package lib
 
 import (
 	"bufio"
+	"fmt"
+
+	"common/parsing"
 )
 
 type Coord struct {
@@ -46,18 +49,87 @@ type Cell struct {
 	Boundaries [4]bool
 }
 
-func ReadInput(scanner *bufio.Scanner) [][]Cell {
+func ReadInput(scanner *bufio.Scanner) ([][]Cell, error) {
 	board := make([][]Cell, 0)
 	for scanner.Scan() {
 		line := scanner.Text()
 		iRow := len(board)
 		row := make([]Cell, 0)
 		for iCol, c := range line {
-			coord := Coord{Row: iRow, Col: iCol}
+			if iRow > 0 && len(row) == len(board[0]) {
+				return nil, parsing.Errorf(iRow+1, iCol+1, line[iCol:], "row is longer than the first row (%d plots)", len(board[0]))
+			}
+			coord := Coord{Row: iRow, Col: len(row)}
 			row = append(row, Cell{Kind: c, Coord: coord, Boundaries: [4]bool{true, true, true, true}})
 		}
+		if iRow > 0 && len(row) < len(board[0]) {
+			return nil, parsing.Errorf(iRow+1, len(line)+1, "", "row is shorter than the first row (%d plots)", len(board[0]))
+		}
 		board = append(board, row)
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+
+	return board, nil
+}
+
+// LabelRegions assigns every cell the number of the region it belongs to, in a single pass over the board with a
+// union-find of same-kind neighbours. Regions are numbered from zero, in reading order of their first cell. The board
+// must be rectangular.
+func LabelRegions(board [][]Cell) ([][]int, int, error) {
+	nCols := 0
+	if len(board) > 0 {
+		nCols = len(board[0])
+	}
+	for iRow, row := range board {
+		if len(row) != nCols {
+			return nil, 0, fmt.Errorf("row %d has %d plots, but the first row has %d", iRow+1, len(row), nCols)
+		}
+	}
+
+	parents := make([]int, len(board)*nCols)
+	find := func(index int) int {
+		for parents[index] != index {
+			parents[index] = parents[parents[index]]
+			index = parents[index]
+		}
+		return index
+	}
+	union := func(a, b int) {
+		rootA, rootB := find(a), find(b)
+		if rootA != rootB {
+			parents[max(rootA, rootB)] = min(rootA, rootB)
+		}
+	}
+
+	for iRow, row := range board {
+		for iCol, cell := range row {
+			index := iRow*nCols + iCol
+			parents[index] = index
+			if iRow > 0 && board[iRow-1][iCol].Kind == cell.Kind {
+				union(index, index-nCols)
+			}
+			if iCol > 0 && row[iCol-1].Kind == cell.Kind {
+				union(index, index-1)
+			}
+		}
+	}
+
+	labelByRoot := make(map[int]int)
+	labels := make([][]int, len(board))
+	for iRow, row := range board {
+		labels[iRow] = make([]int, len(row))
+		for iCol := range row {
+			root := find(iRow*nCols + iCol)
+			label, ok := labelByRoot[root]
+			if !ok {
+				label = len(labelByRoot)
+				labelByRoot[root] = label
+			}
+			labels[iRow][iCol] = label
+		}
+	}
 
-	return board
+	return labels, len(labelByRoot), nil
 }
@@ -0,0 +1,99 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"daytwelve/a/lib"
+
+	"github.com/samber/lo"
+)
+
+type Args struct {
+	command.Flags
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(_ context.Context, args Args, input io.Reader, out *report.Report) error {
+	board, err := readInput(input, out)
+	if err != nil {
+		return err
+	}
+	if len(board) < 1 {
+		return errors.New("board is empty")
+	}
+
+	dimensions := lib.Coord{Row: len(board), Col: len(board[0])}
+
+	antiDirs := make(map[int]int)
+	for iDir, dir := range lib.Directions {
+		antiDir := lo.IndexOf(lib.Directions, lib.Coord{Row: -dir.Row, Col: -dir.Col})
+		if antiDir < 0 {
+			log.Panic("invalid anti-direction")
+		}
+		antiDirs[iDir] = antiDir
+	}
+
+	for iRow := range dimensions.Row {
+		for iCol := range dimensions.Col {
+			cell := &board[iRow][iCol]
+			for iDir, dir := range lib.Directions {
+				neighborCoord := cell.Coord.Add(dir)
+				if !neighborCoord.IsValid(dimensions) {
+					continue
+				}
+				neighborCell := &board[neighborCoord.Row][neighborCoord.Col]
+				if cell.Kind == neighborCell.Kind {
+					cell.Boundaries[iDir] = false
+					neighborCell.Boundaries[antiDirs[iDir]] = false
+				}
+			}
+		}
+	}
+
+	labels, nRegions, err := lib.LabelRegions(board)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+	areas := make([]int, nRegions)
+	fences := make([]int, nRegions)
+	for iRow := range dimensions.Row {
+		for iCol := range dimensions.Col {
+			label := labels[iRow][iCol]
+			areas[label]++
+			fences[label] += lo.Count(board[iRow][iCol].Boundaries[:], true)
+		}
+	}
+
+	totalFence := 0
+	totalArea := 0
+	totalCost := 0
+	for label := range nRegions {
+		totalFence += fences[label]
+		totalArea += areas[label]
+		totalCost += areas[label] * fences[label]
+	}
+
+	slog.Info("solved", "totalArea", totalArea, "totalFence", totalFence, "totalCost", totalCost)
+	out.Parsed("rows", dimensions.Row)
+	out.Parsed("cols", dimensions.Col)
+	out.Answer("totalArea", totalArea)
+	out.Answer("totalFence", totalFence)
+	out.Answer("totalCost", totalCost)
+
+	return nil
+}
+
+func readInput(input io.Reader, out *report.Report) ([][]lib.Cell, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	values, err := lib.ReadInput(scanner)
+
+	return values, err //nolint:wrapcheck // Toy code
+}
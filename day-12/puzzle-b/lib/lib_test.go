@@ -0,0 +1,128 @@
+package lib
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readBoard(input string) ([][]Cell, error) {
+	return ReadInput(bufio.NewScanner(strings.NewReader(input)))
+}
+
+// printBoard writes the board back out in the puzzle format.
+func printBoard(board [][]Cell) string {
+	var builder strings.Builder
+	for _, row := range board {
+		for _, cell := range row {
+			builder.WriteRune(cell.Kind)
+		}
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("AAAA\nBBCD\nBBCC\nEEEC\n")
+	f.Add("OOOOO\nOXOXO\nOOOOO\nOXOXO\nOOOOO\n")
+	f.Add("RRRRIICCFF\nRRRRIICCCF\nVVRRRCCFFF\nVVRCCCJFFF\nVVVVCJJCFE\n")
+	f.Add("AB\nABC\n")
+	f.Add("ABC\nA\n")
+	f.Add("\nA\n")
+	f.Add("é\nß\n")
+	f.Fuzz(func(t *testing.T, input string) {
+		board, err := readBoard(input)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+		for iRow, row := range board {
+			require.Len(t, row, len(board[0]))
+			for iCol, cell := range row {
+				assert.Equal(t, Coord{Row: iRow, Col: iCol}, cell.Coord)
+			}
+		}
+
+		// A plot kind of \r ending a row would be read back as part of a \r\n line ending.
+		if !strings.Contains(input, "\r") {
+			reread, err := readBoard(printBoard(board))
+			require.NoError(t, err)
+			assert.Equal(t, board, reread)
+		}
+
+		labels, nRegions, err := LabelRegions(board)
+		require.NoError(t, err)
+		for iRow, row := range board {
+			for iCol, cell := range row {
+				label := labels[iRow][iCol]
+				assert.True(t, label >= 0 && label < nRegions)
+				if iCol > 0 && row[iCol-1].Kind == cell.Kind {
+					assert.Equal(t, labels[iRow][iCol-1], label)
+				}
+				if iRow > 0 && board[iRow-1][iCol].Kind == cell.Kind {
+					assert.Equal(t, labels[iRow-1][iCol], label)
+				}
+			}
+		}
+	})
+}
+
+func TestLabelRegions(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		labels   [][]int
+		nRegions int
+	}{
+		{
+			name:     "example",
+			input:    "AAAA\nBBCD\nBBCC\nEEEC\n",
+			labels:   [][]int{{0, 0, 0, 0}, {1, 1, 2, 3}, {1, 1, 2, 2}, {4, 4, 4, 2}},
+			nRegions: 5,
+		},
+		{
+			// The arms of the U only meet on the last row, after the B between them was numbered.
+			name:     "U shape",
+			input:    "ABA\nAAA\n",
+			labels:   [][]int{{0, 1, 0}, {0, 0, 0}},
+			nRegions: 2,
+		},
+		{
+			// The arms of the spiral are joined one at a time, so the roots chain up.
+			name:     "spiral",
+			input:    "AAAAA\nBBBBA\nAAABA\nABBBA\nAAAAA\n",
+			labels:   [][]int{{0, 0, 0, 0, 0}, {1, 1, 1, 1, 0}, {0, 0, 0, 1, 0}, {0, 1, 1, 1, 0}, {0, 0, 0, 0, 0}},
+			nRegions: 2,
+		},
+		{
+			name:     "same kind apart",
+			input:    "ABA\n",
+			labels:   [][]int{{0, 1, 2}},
+			nRegions: 3,
+		},
+		{name: "empty", input: "", labels: [][]int{}, nRegions: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			board, err := readBoard(test.input)
+			require.NoError(t, err)
+			labels, nRegions, err := LabelRegions(board)
+			require.NoError(t, err)
+			assert.Equal(t, test.labels, labels)
+			assert.Equal(t, test.nRegions, nRegions)
+		})
+	}
+}
+
+func TestLabelRegionsRagged(t *testing.T) {
+	board, err := readBoard("AB\nAB\nAB\n")
+	require.NoError(t, err)
+	board[1] = board[1][:1]
+	_, _, err = LabelRegions(board)
+	assert.EqualError(t, err, "row 2 has 1 plots, but the first row has 2")
+}
@@ -0,0 +1,449 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+
+	"common/command"
+	"common/report"
+	"daytwelve/b/lib"
+
+	"github.com/hashicorp/go-set/v3"
+	"github.com/samber/lo"
+)
+
+type Args struct {
+	command.Flags
+
+	Report  string `arg:"--report"            help:"write a per-region report to stdout, as csv or json"`
+	SVGFile string `arg:"--svg"               help:"write an SVG outlining every region's fence sides to this file"`
+}
+
+// Region describes one connected region of the garden. Regions enclosed by another region's holes name the innermost
+// such region in EnclosedBy, which is -1 for regions that are not enclosed.
+type Region struct {
+	ID         int         `json:"id"`
+	Kind       string      `json:"kind"`
+	Area       int         `json:"area"`
+	Perimeter  int         `json:"perimeter"`
+	Sides      int         `json:"sides"`
+	Holes      int         `json:"holes"`
+	Min        lib.Coord   `json:"min"`
+	Max        lib.Coord   `json:"max"`
+	EnclosedBy int         `json:"enclosedBy"`
+	Cells      []lib.Coord `json:"-"`
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(_ context.Context, args Args, input io.Reader, out *report.Report) error {
+	if out.IsJSON() && args.Report != "" {
+		return errors.New("--report writes to stdout and cannot be combined with --output json")
+	}
+
+	board, err := readInput(input, out)
+	if err != nil {
+		return err
+	}
+	if len(board) < 1 {
+		return errors.New("board is empty")
+	}
+
+	dimensions := lib.Coord{Row: len(board), Col: len(board[0])}
+
+	labels, nRegions, err := lib.LabelRegions(board)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+	markBoundaries(board, dimensions)
+	areas, corners := measureRegions(board, dimensions, labels, nRegions)
+
+	totalCorners := 0
+	totalArea := 0
+	totalCost := 0
+	for label := range nRegions {
+		totalCorners += corners[label]
+		totalArea += areas[label]
+		totalCost += areas[label] * corners[label]
+	}
+
+	slog.Info("solved", "totalArea", totalArea, "totalCorners", totalCorners, "totalCost", totalCost)
+	out.Parsed("rows", dimensions.Row)
+	out.Parsed("cols", dimensions.Col)
+	out.Answer("totalArea", totalArea)
+	out.Answer("totalCorners", totalCorners)
+	out.Answer("totalCost", totalCost)
+
+	if args.Report == "" && args.SVGFile == "" {
+		return nil
+	}
+
+	regions := makeRegions(board, labels, areas, corners)
+	describeRegions(board, dimensions, labels, regions)
+	switch args.Report {
+	case "":
+	case "csv":
+		err := writeCSVReport(os.Stdout, regions)
+		if err != nil {
+			return err
+		}
+	case "json":
+		err := writeJSONReport(os.Stdout, regions)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unrecognized report format: %s", args.Report)
+	}
+
+	if args.SVGFile != "" {
+		return writeSVGFile(args.SVGFile, board, dimensions, regions)
+	}
+
+	return nil
+}
+
+// markBoundaries clears the boundaries between neighbouring cells of the same kind.
+func markBoundaries(board [][]lib.Cell, dimensions lib.Coord) {
+	antiDirs := make(map[int]int)
+	for iDir, dir := range lib.Directions {
+		antiDir := lo.IndexOf(lib.Directions, lib.Coord{Row: -dir.Row, Col: -dir.Col})
+		if antiDir < 0 {
+			log.Panic("invalid anti-direction")
+		}
+		antiDirs[iDir] = antiDir
+	}
+
+	for iRow := range dimensions.Row {
+		for iCol := range dimensions.Col {
+			cell := &board[iRow][iCol]
+			for iDir, dir := range lib.Directions {
+				neighborCoord := cell.Coord.Add(dir)
+				if !neighborCoord.IsValid(dimensions) {
+					continue
+				}
+				neighborCell := &board[neighborCoord.Row][neighborCoord.Col]
+				if cell.Kind == neighborCell.Kind {
+					cell.Boundaries[iDir] = false
+					neighborCell.Boundaries[antiDirs[iDir]] = false
+				}
+			}
+		}
+	}
+}
+
+// measureRegions returns the area and the number of corners, which is also the number of sides, of every region.
+func measureRegions(board [][]lib.Cell, dimensions lib.Coord, labels [][]int, nRegions int) ([]int, []int) {
+	cornerDict := make([][2]int, 4) //nolint:mnd // Four corners
+	for iCorner, corner := range lib.Corners {
+		items := corner.Slice()
+		if len(items) != 2 {
+			log.Panic("internal error: problem in lib.Corners (len(items) != 2)")
+		}
+
+		dir1, dir2 := items[0], items[1]
+		iDir1 := lo.IndexOf(lib.Directions, dir1)
+		iDir2 := lo.IndexOf(lib.Directions, dir2)
+		if iDir1 < 0 || iDir2 < 0 {
+			log.Panic("internal error: problem in lib.Corners (iDir1 < 0 || iDir2 < 0)")
+		}
+
+		cornerDict[iCorner] = [2]int{iDir1, iDir2}
+	}
+
+	areas := make([]int, nRegions)
+	corners := make([]int, nRegions)
+	for iRow := range dimensions.Row {
+		for iCol := range dimensions.Col {
+			label := labels[iRow][iCol]
+			areas[label]++
+			corners[label] += countCorners(board, dimensions, lib.Coord{Row: iRow, Col: iCol}, cornerDict)
+		}
+	}
+
+	return areas, corners
+}
+
+// makeRegions gathers the cells of every region, in reading order.
+func makeRegions(board [][]lib.Cell, labels [][]int, areas, corners []int) []Region {
+	regions := make([]Region, len(areas))
+	for iRow, row := range labels {
+		for iCol, label := range row {
+			region := &regions[label]
+			if region.Cells == nil {
+				region.Kind = string(board[iRow][iCol].Kind)
+				region.Area = areas[label]
+				region.Sides = corners[label]
+			}
+			region.Cells = append(region.Cells, lib.Coord{Row: iRow, Col: iCol})
+		}
+	}
+
+	return regions
+}
+
+func countCorners(board [][]lib.Cell, dimensions, coord lib.Coord, cornerDict [][2]int) int {
+	cell := &board[coord.Row][coord.Col]
+	selfCorners := 0
+	for _, dirs := range cornerDict {
+		if cell.Boundaries[dirs[0]] && cell.Boundaries[dirs[1]] {
+			selfCorners++
+		}
+	}
+
+	convexCorners := 0
+	for _, dirs := range cornerDict {
+		if cell.Boundaries[dirs[0]] || cell.Boundaries[dirs[1]] {
+			continue
+		}
+
+		diagNeighborCoords := coord.Add(lib.Directions[dirs[0]]).Add(lib.Directions[dirs[1]])
+		if !diagNeighborCoords.IsValid(dimensions) {
+			continue
+		}
+
+		diagNeighbor := &board[diagNeighborCoords.Row][diagNeighborCoords.Col]
+		if diagNeighbor.Kind != cell.Kind {
+			convexCorners++
+		}
+	}
+
+	return selfCorners + convexCorners
+}
+
+// describeRegions fills in the ID, perimeter, bounding box, holes and enclosing region of every region. A hole is a
+// group of cells outside the region, connected through edges or corners, that cannot reach the outside of the region's
+// bounding box; every region lying in a hole is enclosed by the region.
+func describeRegions(board [][]lib.Cell, dimensions lib.Coord, labels [][]int, regions []Region) {
+	enclosers := make([][]int, len(regions))
+	for iRegion := range regions {
+		region := &regions[iRegion]
+		region.ID = iRegion
+		region.Min = region.Cells[0]
+		region.Max = region.Cells[0]
+		for _, coord := range region.Cells {
+			region.Min = lib.Coord{Row: min(region.Min.Row, coord.Row), Col: min(region.Min.Col, coord.Col)}
+			region.Max = lib.Coord{Row: max(region.Max.Row, coord.Row), Col: max(region.Max.Col, coord.Col)}
+			region.Perimeter += lo.Count(board[coord.Row][coord.Col].Boundaries[:], true)
+		}
+
+		for _, hole := range findHoles(labels, dimensions, *region) {
+			region.Holes++
+			for _, coord := range hole {
+				enclosed := labels[coord.Row][coord.Col]
+				if !lo.Contains(enclosers[enclosed], region.ID) {
+					enclosers[enclosed] = append(enclosers[enclosed], region.ID)
+				}
+			}
+		}
+	}
+
+	// The regions around a region are nested, so the innermost one is the one enclosed by all the others.
+	for iRegion := range regions {
+		regions[iRegion].EnclosedBy = -1
+		if len(enclosers[iRegion]) > 0 {
+			regions[iRegion].EnclosedBy = lo.MaxBy(enclosers[iRegion], func(a, b int) bool {
+				return len(enclosers[a]) > len(enclosers[b])
+			})
+		}
+	}
+}
+
+func findHoles(labels [][]int, dimensions lib.Coord, region Region) [][]lib.Coord {
+	boxMin := region.Min.Subtract(lib.Coord{Row: 1, Col: 1})
+	boxMax := region.Max.Add(lib.Coord{Row: 1, Col: 1})
+	inBox := func(coord lib.Coord) bool {
+		return coord.Row >= boxMin.Row && coord.Row <= boxMax.Row && coord.Col >= boxMin.Col && coord.Col <= boxMax.Col
+	}
+	isRegion := func(coord lib.Coord) bool {
+		return coord.IsValid(dimensions) && labels[coord.Row][coord.Col] == region.ID
+	}
+
+	visited := set.New[lib.Coord](0)
+	var holes [][]lib.Coord
+	for iRow := boxMin.Row; iRow <= boxMax.Row; iRow++ {
+		for iCol := boxMin.Col; iCol <= boxMax.Col; iCol++ {
+			start := lib.Coord{Row: iRow, Col: iCol}
+			if isRegion(start) || visited.Contains(start) {
+				continue
+			}
+
+			var component []lib.Coord
+			isOutside := false
+			stack := []lib.Coord{start}
+			visited.Insert(start)
+			for len(stack) > 0 {
+				coord := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				component = append(component, coord)
+				if coord.Row == boxMin.Row || coord.Row == boxMax.Row || coord.Col == boxMin.Col || coord.Col == boxMax.Col {
+					isOutside = true
+				}
+
+				for _, dir := range kingDirections {
+					neighbor := coord.Add(dir)
+					if !inBox(neighbor) || isRegion(neighbor) || visited.Contains(neighbor) {
+						continue
+					}
+					visited.Insert(neighbor)
+					stack = append(stack, neighbor)
+				}
+			}
+
+			if !isOutside {
+				holes = append(holes, component)
+			}
+		}
+	}
+
+	return holes
+}
+
+var kingDirections = []lib.Coord{ //nolint:gochecknoglobals // Meant as a constant
+	{Row: -1, Col: -1}, {Row: -1, Col: 0}, {Row: -1, Col: 1},
+	{Row: 0, Col: -1}, {Row: 0, Col: 1},
+	{Row: 1, Col: -1}, {Row: 1, Col: 0}, {Row: 1, Col: 1},
+}
+
+func writeCSVReport(w io.Writer, regions []Region) error {
+	csvWriter := csv.NewWriter(w)
+	err := csvWriter.Write([]string{
+		"id", "kind", "area", "perimeter", "sides", "holes", "minRow", "minCol", "maxRow", "maxCol", "enclosedBy",
+	})
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	for _, region := range regions {
+		err := csvWriter.Write(lo.Map([]any{
+			region.ID, region.Kind, region.Area, region.Perimeter, region.Sides, region.Holes,
+			region.Min.Row, region.Min.Col, region.Max.Row, region.Max.Col, region.EnclosedBy,
+		}, func(item any, _ int) string {
+			return fmt.Sprint(item)
+		}))
+		if err != nil {
+			return err //nolint:wrapcheck // Toy code
+		}
+	}
+
+	csvWriter.Flush()
+
+	return csvWriter.Error() //nolint:wrapcheck // Toy code
+}
+
+func writeJSONReport(w io.Writer, regions []Region) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(regions) //nolint:wrapcheck // Toy code
+}
+
+const svgCellSize = 10
+
+// writeSVGFile draws every region filled with a colour of its kind, and outlines it with one line per fence side, so
+// that the sides can be counted on the drawing.
+func writeSVGFile(path string, board [][]lib.Cell, dimensions lib.Coord, regions []Region) (err error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	w := bufio.NewWriter(file)
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d">`+"\n",
+		(dimensions.Col+2)*svgCellSize, (dimensions.Row+2)*svgCellSize)
+	fmt.Fprintf(w, `<g transform="translate(%d,%d)">`+"\n", svgCellSize, svgCellSize)
+	for _, region := range regions {
+		hue := (int([]rune(region.Kind)[0]) * 47) % 360 //nolint:mnd // Spread the kinds around the colour wheel
+		fmt.Fprintf(w, `<g id="region-%d"><title>%s #%d: area %d, perimeter %d, sides %d, holes %d</title>`+"\n",
+			region.ID, html.EscapeString(region.Kind), region.ID, region.Area, region.Perimeter, region.Sides, region.Holes)
+		for _, coord := range region.Cells {
+			fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" fill="hsl(%d,60%%,80%%)"/>`+"\n",
+				coord.Col*svgCellSize, coord.Row*svgCellSize, svgCellSize, svgCellSize, hue)
+		}
+		for _, side := range fenceSides(board, region) {
+			fmt.Fprintf(w, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="hsl(%d,60%%,30%%)" stroke-width="2"/>`+"\n",
+				side[0].Col*svgCellSize, side[0].Row*svgCellSize, side[1].Col*svgCellSize, side[1].Row*svgCellSize, hue)
+		}
+		fmt.Fprintln(w, `</g>`)
+	}
+	fmt.Fprintln(w, `</g>`)
+	fmt.Fprintln(w, `</svg>`)
+
+	return w.Flush() //nolint:wrapcheck // Toy code
+}
+
+// fenceSides returns the end points, in cell-corner coordinates, of every side of the region's fence: a maximal run of
+// fence segments facing the same way along the same line.
+func fenceSides(board [][]lib.Cell, region Region) [][2]lib.Coord {
+	var sides [][2]lib.Coord
+	for iDir, dir := range lib.Directions {
+		// Walk the runs rightwards or downwards, whichever way the fence faces, so that they start at their top left.
+		along := lib.Coord{Row: abs(dir.Col), Col: abs(dir.Row)}
+		for _, coord := range region.Cells {
+			if !board[coord.Row][coord.Col].Boundaries[iDir] {
+				continue
+			}
+
+			isFenced := func(other lib.Coord) bool {
+				if other.Row < 0 || other.Row >= len(board) || other.Col < 0 || other.Col >= len(board[other.Row]) {
+					return false
+				}
+				otherCell := board[other.Row][other.Col]
+				return otherCell.Kind == board[coord.Row][coord.Col].Kind && otherCell.Boundaries[iDir]
+			}
+			if isFenced(coord.Subtract(along)) {
+				continue
+			}
+
+			end := coord
+			for isFenced(end.Add(along)) {
+				end = end.Add(along)
+			}
+
+			sides = append(sides, fenceSegment(coord, end, dir))
+		}
+	}
+
+	return sides
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// fenceSegment converts a run of cells, from start to end, fenced in the given direction into the end points of the
+// fence in cell-corner coordinates.
+func fenceSegment(start, end, dir lib.Coord) [2]lib.Coord {
+	switch dir {
+	case lib.Coord{Row: -1, Col: 0}:
+		return [2]lib.Coord{start, end.Add(lib.Coord{Row: 0, Col: 1})}
+	case lib.Coord{Row: 1, Col: 0}:
+		return [2]lib.Coord{start.Add(lib.Coord{Row: 1, Col: 0}), end.Add(lib.Coord{Row: 1, Col: 1})}
+	case lib.Coord{Row: 0, Col: -1}:
+		return [2]lib.Coord{start, end.Add(lib.Coord{Row: 1, Col: 0})}
+	default:
+		return [2]lib.Coord{start.Add(lib.Coord{Row: 0, Col: 1}), end.Add(lib.Coord{Row: 1, Col: 1})}
+	}
+}
+
+func readInput(input io.Reader, out *report.Report) ([][]lib.Cell, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	values, err := lib.ReadInput(scanner)
+
+	return values, err //nolint:wrapcheck // Toy code
+}
@@ -0,0 +1,216 @@
+package solver
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"daytwelve/b/lib"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// describe reads a board and describes its regions the way Solve does for the report.
+func describe(t *testing.T, input string) ([][]lib.Cell, lib.Coord, []Region) {
+	t.Helper()
+	board, err := lib.ReadInput(bufio.NewScanner(strings.NewReader(input)))
+	require.NoError(t, err)
+	dimensions := lib.Coord{Row: len(board), Col: len(board[0])}
+	labels, nRegions, err := lib.LabelRegions(board)
+	require.NoError(t, err)
+	markBoundaries(board, dimensions)
+	areas, corners := measureRegions(board, dimensions, labels, nRegions)
+	regions := makeRegions(board, labels, areas, corners)
+	describeRegions(board, dimensions, labels, regions)
+	return board, dimensions, regions
+}
+
+func TestDescribeRegions(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		regions []Region
+	}{
+		{
+			name:  "four holes",
+			input: "OOOOO\nOXOXO\nOOOOO\nOXOXO\nOOOOO\n",
+			regions: []Region{
+				{ID: 0, Kind: "O", Area: 21, Perimeter: 36, Sides: 20, Holes: 4, Min: lib.Coord{}, Max: lib.Coord{Row: 4, Col: 4}, EnclosedBy: -1},
+				{ID: 1, Kind: "X", Area: 1, Perimeter: 4, Sides: 4, Min: lib.Coord{Row: 1, Col: 1}, Max: lib.Coord{Row: 1, Col: 1}},
+				{ID: 2, Kind: "X", Area: 1, Perimeter: 4, Sides: 4, Min: lib.Coord{Row: 1, Col: 3}, Max: lib.Coord{Row: 1, Col: 3}},
+				{ID: 3, Kind: "X", Area: 1, Perimeter: 4, Sides: 4, Min: lib.Coord{Row: 3, Col: 1}, Max: lib.Coord{Row: 3, Col: 1}},
+				{ID: 4, Kind: "X", Area: 1, Perimeter: 4, Sides: 4, Min: lib.Coord{Row: 3, Col: 3}, Max: lib.Coord{Row: 3, Col: 3}},
+			},
+		},
+		{
+			// The innermost region is enclosed by the innermost of the regions around it.
+			name:  "nested",
+			input: "AAAAA\nABBBA\nABCBA\nABBBA\nAAAAA\n",
+			regions: []Region{
+				{ID: 0, Kind: "A", Area: 16, Perimeter: 32, Sides: 8, Holes: 1, Min: lib.Coord{}, Max: lib.Coord{Row: 4, Col: 4}, EnclosedBy: -1},
+				{ID: 1, Kind: "B", Area: 8, Perimeter: 16, Sides: 8, Holes: 1, Min: lib.Coord{Row: 1, Col: 1}, Max: lib.Coord{Row: 3, Col: 3}},
+				{ID: 2, Kind: "C", Area: 1, Perimeter: 4, Sides: 4, Min: lib.Coord{Row: 2, Col: 2}, Max: lib.Coord{Row: 2, Col: 2}, EnclosedBy: 1},
+			},
+		},
+		{
+			// The ring around the others is smaller than the region it encloses, yet it is not the innermost.
+			name: "nested in a thin ring",
+			input: "AAAAAAAAAAA\n" + strings.Repeat("ABBBBBBBBBA\n", 4) + "ABBBBCBBBBA\n" + strings.Repeat("ABBBBBBBBBA\n", 4) +
+				"AAAAAAAAAAA\n",
+			regions: []Region{
+				{ID: 0, Kind: "A", Area: 40, Perimeter: 80, Sides: 8, Holes: 1, Min: lib.Coord{}, Max: lib.Coord{Row: 10, Col: 10}, EnclosedBy: -1},
+				{ID: 1, Kind: "B", Area: 80, Perimeter: 40, Sides: 8, Holes: 1, Min: lib.Coord{Row: 1, Col: 1}, Max: lib.Coord{Row: 9, Col: 9}},
+				{ID: 2, Kind: "C", Area: 1, Perimeter: 4, Sides: 4, Min: lib.Coord{Row: 5, Col: 5}, Max: lib.Coord{Row: 5, Col: 5}, EnclosedBy: 1},
+			},
+		},
+		{
+			// Cells touching by a corner make up a single hole.
+			name:  "diagonal hole",
+			input: "AAAA\nA.AA\nAA.A\nAAAA\n",
+			regions: []Region{
+				{ID: 0, Kind: "A", Area: 14, Perimeter: 24, Sides: 12, Holes: 1, Min: lib.Coord{}, Max: lib.Coord{Row: 3, Col: 3}, EnclosedBy: -1},
+				{ID: 1, Kind: ".", Area: 1, Perimeter: 4, Sides: 4, Min: lib.Coord{Row: 1, Col: 1}, Max: lib.Coord{Row: 1, Col: 1}},
+				{ID: 2, Kind: ".", Area: 1, Perimeter: 4, Sides: 4, Min: lib.Coord{Row: 2, Col: 2}, Max: lib.Coord{Row: 2, Col: 2}},
+			},
+		},
+		{
+			// The outside of the board is outside of every region.
+			name:  "open to the edge",
+			input: "AB\nAA\n",
+			regions: []Region{
+				{ID: 0, Kind: "A", Area: 3, Perimeter: 8, Sides: 6, Min: lib.Coord{}, Max: lib.Coord{Row: 1, Col: 1}, EnclosedBy: -1},
+				{ID: 1, Kind: "B", Area: 1, Perimeter: 4, Sides: 4, Min: lib.Coord{Row: 0, Col: 1}, Max: lib.Coord{Row: 0, Col: 1}, EnclosedBy: -1},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, regions := describe(t, test.input)
+			require.Len(t, regions, len(test.regions))
+			for iRegion, region := range regions {
+				assert.Len(t, region.Cells, region.Area)
+				region.Cells = nil
+				assert.Equal(t, test.regions[iRegion], region)
+			}
+		})
+	}
+}
+
+func TestFindHoles(t *testing.T) {
+	board, dimensions, regions := describe(t, "AAAAA\nA.A.A\nAAAAA\nAA..A\nAAAAA\n")
+	labels, _, err := lib.LabelRegions(board)
+	require.NoError(t, err)
+	holes := findHoles(labels, dimensions, regions[0])
+	assert.ElementsMatch(t, [][]lib.Coord{
+		{{Row: 1, Col: 1}},
+		{{Row: 1, Col: 3}},
+		{{Row: 3, Col: 2}, {Row: 3, Col: 3}},
+	}, sortedHoles(holes))
+	assert.Empty(t, findHoles(labels, dimensions, regions[1]))
+}
+
+// sortedHoles orders the cells of every hole, which come in search order.
+func sortedHoles(holes [][]lib.Coord) [][]lib.Coord {
+	for _, hole := range holes {
+		slices.SortFunc(hole, func(a, b lib.Coord) int {
+			return cmp.Or(cmp.Compare(a.Row, b.Row), cmp.Compare(a.Col, b.Col))
+		})
+	}
+	return holes
+}
+
+func TestFenceSides(t *testing.T) {
+	board, _, regions := describe(t, "X\n")
+	assert.Equal(t, [][2]lib.Coord{
+		{{Row: 1, Col: 0}, {Row: 1, Col: 1}},
+		{{Row: 0, Col: 1}, {Row: 1, Col: 1}},
+		{{Row: 0, Col: 0}, {Row: 1, Col: 0}},
+		{{Row: 0, Col: 0}, {Row: 0, Col: 1}},
+	}, fenceSides(board, regions[0]))
+
+	board, _, regions = describe(t, "AAAA\nBBCD\nBBCC\nEEEC\n")
+	assert.Equal(t, [][2]lib.Coord{
+		{{Row: 1, Col: 0}, {Row: 1, Col: 4}},
+		{{Row: 0, Col: 4}, {Row: 1, Col: 4}},
+		{{Row: 0, Col: 0}, {Row: 1, Col: 0}},
+		{{Row: 0, Col: 0}, {Row: 0, Col: 4}},
+	}, fenceSides(board, regions[0]))
+
+	// Every side is drawn once, so there are as many as corners.
+	paths, err := filepath.Glob("../examples/*.txt")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+	for _, path := range paths {
+		input, err := os.ReadFile(path)
+		require.NoError(t, err)
+		board, _, regions := describe(t, string(input))
+		for _, region := range regions {
+			assert.Len(t, fenceSides(board, region), region.Sides, "%s, region %d", path, region.ID)
+		}
+	}
+}
+
+func TestWriteCSVReport(t *testing.T) {
+	_, _, regions := describe(t, "AAB\n")
+	var buffer bytes.Buffer
+	require.NoError(t, writeCSVReport(&buffer, regions))
+	assert.Equal(t, "id,kind,area,perimeter,sides,holes,minRow,minCol,maxRow,maxCol,enclosedBy\n"+
+		"0,A,2,6,4,0,0,0,0,1,-1\n"+
+		"1,B,1,4,4,0,0,2,0,2,-1\n", buffer.String())
+}
+
+func TestWriteJSONReport(t *testing.T) {
+	_, _, regions := describe(t, "AAB\n")
+	var buffer bytes.Buffer
+	require.NoError(t, writeJSONReport(&buffer, regions))
+	assert.JSONEq(t, `[
+		{"id": 0, "kind": "A", "area": 2, "perimeter": 6, "sides": 4, "holes": 0,
+			"min": {"Row": 0, "Col": 0}, "max": {"Row": 0, "Col": 1}, "enclosedBy": -1},
+		{"id": 1, "kind": "B", "area": 1, "perimeter": 4, "sides": 4, "holes": 0,
+			"min": {"Row": 0, "Col": 2}, "max": {"Row": 0, "Col": 2}, "enclosedBy": -1}
+	]`, buffer.String())
+}
+
+func TestWriteSVGFile(t *testing.T) {
+	// Kinds that need escaping in the titles.
+	board, dimensions, regions := describe(t, "&<\n<<\n")
+	path := filepath.Join(t.TempDir(), "fences.svg")
+	require.NoError(t, writeSVGFile(path, board, dimensions, regions))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	counts := make(map[string]int)
+	var titles []string
+	decoder := xml.NewDecoder(file)
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		if start, ok := token.(xml.StartElement); ok {
+			counts[start.Name.Local]++
+			if start.Name.Local == "title" {
+				var title string
+				require.NoError(t, decoder.DecodeElement(&title, &start))
+				titles = append(titles, title)
+			}
+		}
+	}
+
+	assert.Equal(t, map[string]int{"svg": 1, "g": 3, "title": 2, "rect": 4, "line": 10}, counts)
+	assert.Equal(t, []string{
+		"& #0: area 1, perimeter 4, sides 4, holes 0",
+		"< #1: area 3, perimeter 8, sides 6, holes 0",
+	}, titles)
+}
@@ -2,10 +2,10 @@ package lib
 
 import (
 	"bufio"
-	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
+
+	"common/parsing"
 )
 
 type Coord struct {
@@ -42,56 +42,91 @@ type Solution ScalarMachinePair
 
 type Prices ScalarMachinePair
 
+// machineTemplates are the lines describing a machine, in which every %d stands for a positive number.
+var machineTemplates = []string{ //nolint:gochecknoglobals // Meant as a constant
+	"Button A: X+%d, Y+%d",
+	"Button B: X+%d, Y+%d",
+	"Prize: X=%d, Y=%d",
+}
+
 func ReadInput(scanner *bufio.Scanner) ([]Machine, error) {
 	machines := make([]Machine, 0)
-	stringBuffer := strings.Builder{}
+	coords := make([]Coord, 0, len(machineTemplates))
+	lineNum := 0
 	for scanner.Scan() {
+		lineNum++
 		line := scanner.Text()
-		stringBuffer.WriteString(line)
-		stringBuffer.WriteString("\n")
-	}
-
-	pattern := `Button A: X\+([1-9][0-9]*), Y\+([1-9][0-9]*)
-Button B: X\+([1-9][0-9]*), Y\+([1-9][0-9]*)
-Prize: X=([1-9][0-9]*), Y=([1-9][0-9]*)`
-	re, err := regexp.Compile(pattern)
-	if err != nil {
-		return nil, fmt.Errorf("internal error: failed to compile regex: %w", err)
-	}
-
-	allMatches := re.FindAllStringSubmatch(stringBuffer.String(), -1)
-	for _, match := range allMatches {
-		machine := Machine{}
-		if len(match) != 7 { //nolint:mnd // Regexp-dependent, check is for internal error.
-			return nil, fmt.Errorf("internal error: unexpected number of matches: %v", len(match))
+		if len(coords) == 0 && strings.TrimSpace(line) == "" {
+			continue
 		}
-		var err error
-		machine.ButtonA.Row, err = strconv.Atoi(match[1])
-		if err != nil {
-			return nil, fmt.Errorf("internal error: failed to convert button A row: %w (input string: `%v`)", err, match[1])
-		}
-		machine.ButtonA.Col, err = strconv.Atoi(match[2])
+
+		coord, err := parseCoord(line, lineNum, machineTemplates[len(coords)])
 		if err != nil {
-			return nil, fmt.Errorf("internal error: failed to convert button A col: %w (input string: `%v`)", err, match[2])
+			return nil, err
 		}
-		machine.ButtonB.Row, err = strconv.Atoi(match[3])
-		if err != nil {
-			return nil, fmt.Errorf("internal error: failed to convert button B row: %w (input string: `%v`)", err, match[3])
+		coords = append(coords, coord)
+		if len(coords) == len(machineTemplates) {
+			machines = append(machines, Machine{ButtonA: coords[0], ButtonB: coords[1], PrizeLoc: coords[2]})
+			coords = coords[:0]
 		}
-		machine.ButtonB.Col, err = strconv.Atoi(match[4])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+	if len(coords) > 0 {
+		return nil, parsing.Errorf(lineNum+1, 1, "", "unexpected end of input, expected %q", machineTemplates[len(coords)])
+	}
+
+	return machines, nil
+}
+
+// parseCoord reads the X and Y numbers of a machine line into a coordinate.
+func parseCoord(line string, lineNum int, template string) (Coord, error) {
+	numbers, cols, err := matchTemplate(line, lineNum, template)
+	if err != nil {
+		return Coord{}, err
+	}
+	values := make([]int, len(numbers))
+	for iNumber, number := range numbers {
+		values[iNumber], err = strconv.Atoi(number)
 		if err != nil {
-			return nil, fmt.Errorf("internal error: failed to convert button B col: %w (input string: `%v`)", err, match[4])
+			return Coord{}, parsing.Errorf(lineNum, cols[iNumber], number, "number out of range")
 		}
-		machine.PrizeLoc.Row, err = strconv.Atoi(match[5])
-		if err != nil {
-			return nil, fmt.Errorf("internal error: failed to convert prize row: %w (input string: `%v`)", err, match[5])
+	}
+
+	return Coord{Row: values[0], Col: values[1]}, nil
+}
+
+// matchTemplate matches the line against the template, in which every %d stands for a positive number, and returns the
+// numbers along with the 1-based columns at which they start.
+func matchTemplate(line string, lineNum int, template string) ([]string, []int, error) {
+	var numbers []string
+	var cols []int
+	pos := 0
+	for iPart, literal := range strings.Split(template, "%d") {
+		if iPart > 0 {
+			end := pos
+			for end < len(line) && line[end] >= '0' && line[end] <= '9' {
+				end++
+			}
+			if end == pos || line[pos] == '0' {
+				return nil, nil, parsing.Errorf(lineNum, pos+1, line[pos:], "expected a positive number")
+			}
+			numbers = append(numbers, line[pos:end])
+			cols = append(cols, pos+1)
+			pos = end
 		}
-		machine.PrizeLoc.Col, err = strconv.Atoi(match[6])
-		if err != nil {
-			return nil, fmt.Errorf("internal error: failed to convert prize col: %w (input string: `%v`)", err, match[6])
+
+		for iByte := range len(literal) {
+			if pos+iByte >= len(line) || line[pos+iByte] != literal[iByte] {
+				return nil, nil, parsing.Errorf(lineNum, pos+iByte+1, line[min(pos+iByte, len(line)):], "expected %q", literal[iByte:])
+			}
 		}
-		machines = append(machines, machine)
+		pos += len(literal)
+	}
+	if pos < len(line) {
+		return nil, nil, parsing.Errorf(lineNum, pos+1, line[pos:], "unexpected trailing text")
 	}
 
-	return machines, nil
+	return numbers, cols, nil
 }
@@ -0,0 +1,86 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"daythirteen/a/lib"
+)
+
+// cancelCheckInterval is the number of presses of button A tried between checks for cancellation, as the maximum number
+// of steps may be too large to try them all.
+const cancelCheckInterval = 1 << 16
+
+type Args struct {
+	command.Flags
+
+	NumMaxSteps int `arg:"-n, --max-steps"     default:"100"     help:"maximum number of steps"`
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	machines, err := readInput(input, out)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	slog.Debug("read machines", "machines", len(machines))
+
+	totalCost := 0
+	prices := lib.Prices{A: 3, B: 1}
+	for iMachine, machine := range machines {
+		var bestPrice int
+		alreadySolved := false
+		for numA := range args.NumMaxSteps {
+			if numA%cancelCheckInterval == 0 {
+				err = ctx.Err()
+				if err != nil {
+					return err //nolint:wrapcheck // Toy code
+				}
+			}
+
+			numB := (machine.PrizeLoc.Row - numA*machine.ButtonA.Row) / machine.ButtonB.Row
+			if numB >= args.NumMaxSteps {
+				continue
+			}
+
+			price := numA*prices.A + numB*prices.B
+			result := machine.ButtonA.Mul(numA)
+			result = result.Add(machine.ButtonB.Mul(numB))
+			if result == machine.PrizeLoc {
+				if !alreadySolved {
+					alreadySolved = true
+					bestPrice = price
+					continue
+				}
+
+				bestPrice = min(bestPrice, price)
+			}
+		}
+		if !alreadySolved {
+			slog.Debug("machine not solvable", "machine", iMachine)
+			continue
+		}
+
+		totalCost += bestPrice
+		slog.Debug("machine solvable", "machine", iMachine, "bestPrice", bestPrice)
+	}
+
+	slog.Info("solved", "totalCost", totalCost)
+	out.Parsed("machines", len(machines))
+	out.Answer("totalCost", totalCost)
+
+	return nil
+}
+
+func readInput(input io.Reader, out *report.Report) ([]lib.Machine, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	values, err := lib.ReadInput(scanner)
+
+	return values, err //nolint:wrapcheck // Toy code
+}
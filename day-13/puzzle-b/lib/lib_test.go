@@ -0,0 +1,51 @@
+package lib
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"testing"
+
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readMachines(input string) ([]Machine, error) {
+	return ReadInput(bufio.NewScanner(strings.NewReader(input)))
+}
+
+// printMachines writes the machines back out in the puzzle format.
+func printMachines(machines []Machine) string {
+	var builder strings.Builder
+	for _, machine := range machines {
+		for iCoord, coord := range []Coord{machine.ButtonA, machine.ButtonB, machine.PrizeLoc} {
+			fmt.Fprintf(&builder, machineTemplates[iCoord]+"\n", coord.Row, coord.Col)
+		}
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("Button A: X+94, Y+34\nButton B: X+22, Y+67\nPrize: X=8400, Y=5400\n\n" +
+		"Button A: X+26, Y+66\nButton B: X+67, Y+21\nPrize: X=12748, Y=12176\n")
+	f.Add("Button A: X+1, Y+2\nButton B: X+3, Y+4\n")
+	f.Add("Button A: X+1, Y+2\nButton B: X+3, Y+4\nPrize: X=5, Y=6 extra\n")
+	f.Add("Button A: X+01, Y+2\n")
+	f.Add("Button A: X+99999999999999999999, Y+2\n")
+	f.Add("Button B: X+1, Y+2\n")
+	f.Add("\n\n\nButton A: X+1,Y+2\n")
+	f.Fuzz(func(t *testing.T, input string) {
+		machines, err := readMachines(input)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+
+		reread, err := readMachines(printMachines(machines))
+		require.NoError(t, err)
+		assert.Equal(t, printMachines(machines), printMachines(reread))
+	})
+}
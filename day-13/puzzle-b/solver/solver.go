@@ -0,0 +1,118 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log"
+	"log/slog"
+	"math/big"
+
+	"common/command"
+	"common/report"
+	"daythirteen/b/lib"
+)
+
+type Args struct {
+	command.Flags
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(_ context.Context, args Args, input io.Reader, out *report.Report) error {
+	machines, err := readInput(input, out)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	slog.Debug("read machines", "machines", len(machines))
+
+	totalPrice := big.NewInt(0)
+	bumpSum, ok := big.NewInt(0).SetString("10000000000000", lib.BaseTen)
+	if !ok {
+		log.Panic("internal error: could not set big.Int to bumpSum string")
+	}
+	bump := lib.Coord{
+		Row: big.NewInt(0).Set(bumpSum),
+		Col: big.NewInt(0).Set(bumpSum),
+	}
+	for iMachine, machine := range machines {
+		machine.PrizeLoc = machine.PrizeLoc.Add(bump)
+
+		lcmA := lib.LCM(machine.ButtonA.Row, machine.ButtonA.Col)
+		rowMulA := big.NewInt(0).Quo(lcmA, machine.ButtonA.Row)
+		colMulA := big.NewInt(0).Quo(lcmA, machine.ButtonA.Col)
+
+		newMulRowB := big.NewInt(0).Mul(rowMulA, machine.ButtonB.Row)
+		newMulColB := big.NewInt(0).Mul(colMulA, machine.ButtonB.Col)
+
+		newPrizeLoc := lib.Coord{
+			Row: big.NewInt(0).Mul(machine.PrizeLoc.Row, rowMulA),
+			Col: big.NewInt(0).Mul(machine.PrizeLoc.Col, colMulA),
+		}
+		mulDiffB := big.NewInt(0).Sub(newMulColB, newMulRowB)
+		prizeLocDiffB := big.NewInt(0).Sub(newPrizeLoc.Col, newPrizeLoc.Row)
+		var priceA *big.Int
+
+		if big.NewInt(0).Mod(prizeLocDiffB, mulDiffB).Sign() == 0 {
+			numB := big.NewInt(0).Quo(prizeLocDiffB, mulDiffB)
+			locB := machine.ButtonB.Mul(numB)
+			diff := machine.PrizeLoc.Sub(locB)
+			if big.NewInt(0).Quo(diff.Row, machine.ButtonA.Row).Cmp(big.NewInt(0).Quo(diff.Col, machine.ButtonA.Col)) != 0 {
+				log.Panic("internal error: the math isn't mathing (the quotients are unequal)")
+			}
+			numA := big.NewInt(0).Quo(diff.Row, machine.ButtonA.Row)
+			priceA = big.NewInt(0).Add(big.NewInt(0).Mul(numA, big.NewInt(3)), numB)
+		}
+
+		lcmB := lib.LCM(machine.ButtonB.Row, machine.ButtonB.Col)
+		rowMulB := big.NewInt(0).Quo(lcmB, machine.ButtonB.Row)
+		colMulB := big.NewInt(0).Quo(lcmB, machine.ButtonB.Col)
+
+		newMulRowA := big.NewInt(0).Mul(rowMulB, machine.ButtonA.Row)
+		newMulColA := big.NewInt(0).Mul(colMulB, machine.ButtonA.Col)
+
+		newPrizeLoc = lib.Coord{
+			Row: big.NewInt(0).Mul(machine.PrizeLoc.Row, rowMulB),
+			Col: big.NewInt(0).Mul(machine.PrizeLoc.Col, colMulB),
+		}
+		mulDiffA := big.NewInt(0).Sub(newMulColA, newMulRowA)
+		prizeLocDiffA := big.NewInt(0).Sub(newPrizeLoc.Col, newPrizeLoc.Row)
+		var priceB *big.Int
+
+		if big.NewInt(0).Mod(prizeLocDiffA, mulDiffA).Sign() == 0 {
+			numA := big.NewInt(0).Quo(prizeLocDiffA, mulDiffA)
+			locA := machine.ButtonA.Mul(numA)
+			diff := machine.PrizeLoc.Sub(locA)
+			if big.NewInt(0).Quo(diff.Row, machine.ButtonB.Row).Cmp(big.NewInt(0).Quo(diff.Col, machine.ButtonB.Col)) != 0 {
+				log.Panic("internal error: the math isn't mathing (the quotients are unequal)")
+			}
+			numB := big.NewInt(0).Quo(diff.Row, machine.ButtonB.Row)
+			priceB = big.NewInt(0).Add(big.NewInt(0).Mul(numA, big.NewInt(3)), numB)
+		}
+
+		if (priceA != priceB) && (priceA == nil || priceB == nil || priceA.Cmp(priceB) != 0) {
+			log.Panicf("internal error: the math isn't mathing (priceA: %d, priceB: %d)", priceA, priceB)
+		}
+
+		if priceA == nil {
+			slog.Debug("machine not solvable", "machine", iMachine)
+			continue
+		}
+		slog.Debug("machine solvable", "machine", iMachine, "price", priceA)
+		totalPrice = totalPrice.Add(totalPrice, priceA)
+	}
+
+	slog.Info("solved", "totalPrice", totalPrice)
+	out.Parsed("machines", len(machines))
+	out.Answer("totalPrice", totalPrice)
+
+	return nil
+}
+
+func readInput(input io.Reader, out *report.Report) ([]lib.Machine, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	values, err := lib.ReadInput(scanner)
+
+	return values, err //nolint:wrapcheck // Toy code
+}
@@ -2,10 +2,11 @@ package lib
 
 import (
 	"bufio"
-	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
+	"unicode"
+
+	"common/parsing"
 )
 
 type Coord struct {
@@ -53,46 +54,92 @@ type Robot struct {
 	Vel Coord
 }
 
+// robotTemplate describes a robot line, in which a space stands for any run of blanks and %d for an integer.
+const robotTemplate = "p=%d,%d v=%d,%d"
+
 func ReadInput(scanner *bufio.Scanner) ([]Robot, error) {
 	robots := make([]Robot, 0)
-	stringBuffer := strings.Builder{}
+	lineNum := 0
 	for scanner.Scan() {
+		lineNum++
 		line := scanner.Text()
-		stringBuffer.WriteString(line)
-		stringBuffer.WriteString("\n")
-	}
-
-	pattern := `p=([0-9]*),([0-9]*)\s+v=(-?[0-9]*),(-?[0-9]*)`
-	re, err := regexp.Compile(pattern)
-	if err != nil {
-		return nil, fmt.Errorf("internal error: failed to compile regex: %w", err)
-	}
-
-	allMatches := re.FindAllStringSubmatch(stringBuffer.String(), -1)
-	for _, match := range allMatches {
-		robot := Robot{}
-		if len(match) != 5 { //nolint:mnd // Regexp-dependent, check is for internal error.
-			return nil, fmt.Errorf("internal error: unexpected number of matches: %v", len(match))
+		if strings.TrimSpace(line) == "" {
+			continue
 		}
-		var err error
-		robot.Pos.X, err = strconv.ParseInt(match[1], 10, 64)
+
+		numbers, cols, err := matchTemplate(line, lineNum, robotTemplate)
 		if err != nil {
-			return nil, fmt.Errorf("internal error: failed to convert button A row: %w (input string: `%v`)", err, match[1])
+			return nil, err
 		}
-		robot.Pos.Y, err = strconv.ParseInt(match[2], 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("internal error: failed to convert button A col: %w (input string: `%v`)", err, match[2])
+		values := make([]int64, len(numbers))
+		for iNumber, number := range numbers {
+			values[iNumber], err = strconv.ParseInt(number, 10, 64)
+			if err != nil {
+				return nil, parsing.Errorf(lineNum, cols[iNumber], number, "number out of range")
+			}
 		}
-		robot.Vel.X, err = strconv.ParseInt(match[3], 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("internal error: failed to convert button B row: %w (input string: `%v`)", err, match[3])
-		}
-		robot.Vel.Y, err = strconv.ParseInt(match[4], 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("internal error: failed to convert button B col: %w (input string: `%v`)", err, match[4])
+		for iNumber := range 2 {
+			if values[iNumber] < 0 {
+				return nil, parsing.Errorf(lineNum, cols[iNumber], numbers[iNumber], "position must not be negative")
+			}
 		}
-		robots = append(robots, robot)
+
+		robots = append(robots, Robot{
+			Pos: Coord{X: values[0], Y: values[1]},
+			Vel: Coord{X: values[2], Y: values[3]},
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
 	}
 
 	return robots, nil
 }
+
+// matchTemplate matches the line, ignoring leading and trailing blanks, against the template, and returns the integers
+// standing in for its %d verbs along with the 1-based columns at which they start.
+func matchTemplate(line string, lineNum int, template string) ([]string, []int, error) {
+	var numbers []string
+	var cols []int
+	pos := len(line) - len(strings.TrimLeftFunc(line, unicode.IsSpace))
+	end := len(strings.TrimRightFunc(line, unicode.IsSpace))
+	for iPart, literal := range strings.Split(template, "%d") {
+		if iPart > 0 {
+			start := pos
+			if pos < end && line[pos] == '-' {
+				pos++
+			}
+			digitsStart := pos
+			for pos < end && line[pos] >= '0' && line[pos] <= '9' {
+				pos++
+			}
+			if pos == digitsStart {
+				return nil, nil, parsing.Errorf(lineNum, start+1, line[start:end], "expected an integer")
+			}
+			numbers = append(numbers, line[start:pos])
+			cols = append(cols, start+1)
+		}
+
+		for _, char := range literal {
+			if char == ' ' {
+				blanksStart := pos
+				for pos < end && unicode.IsSpace(rune(line[pos])) {
+					pos++
+				}
+				if pos == blanksStart {
+					return nil, nil, parsing.Errorf(lineNum, pos+1, line[pos:end], "expected a blank")
+				}
+				continue
+			}
+			if pos >= end || rune(line[pos]) != char {
+				return nil, nil, parsing.Errorf(lineNum, pos+1, line[min(pos, end):end], "expected %q", char)
+			}
+			pos++
+		}
+	}
+	if pos < end {
+		return nil, nil, parsing.Errorf(lineNum, pos+1, line[pos:end], "unexpected trailing text")
+	}
+
+	return numbers, cols, nil
+}
@@ -0,0 +1,81 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"dayfourteen/a/lib"
+)
+
+type Args struct {
+	command.Flags
+
+	X           int64 `arg:"-x, --x-dimension"   default:"101"     help:"X dimension of the board"`
+	Y           int64 `arg:"-y, --y-dimension"   default:"103"     help:"Y dimension of the board"`
+	SecondsToFF int64 `arg:"-s, --seconds"       default:"100"     help:"seconds to fast-forward"`
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(_ context.Context, args Args, input io.Reader, out *report.Report) error {
+	if args.X < 2 {
+		return fmt.Errorf("X dimension of board must be at least 2; got %d", args.X)
+	}
+	if args.Y < 2 {
+		return fmt.Errorf("Y dimension of board must be at least 2; got %d", args.Y)
+	}
+
+	dimensions := lib.Coord{X: args.X, Y: args.Y}
+
+	robots, err := readInput(input, out)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	slog.Debug("read robots", "robots", len(robots))
+
+	// Advance all robots
+	for iRobot := range robots {
+		robot := &robots[iRobot]
+		robot.Pos = robot.Vel.Mul(args.SecondsToFF).Add(robot.Pos).ModOther(dimensions).Add(dimensions).ModOther(dimensions)
+	}
+
+	midpoints := dimensions.Div(2)
+	divPoints := midpoints.Add(lib.Coord{X: 1, Y: 1})
+	var quadrantCounts [2][2]int64
+	for _, robot := range robots {
+		if robot.Pos.X == midpoints.X || robot.Pos.Y == midpoints.Y {
+			continue
+		}
+
+		quadrants := robot.Pos.DivOther(divPoints)
+		quadrantCounts[quadrants.X][quadrants.Y]++
+	}
+
+	slog.Debug("quadrant counts", "counts", quadrantCounts)
+
+	product := int64(1)
+	for x := range 2 {
+		for y := range 2 {
+			product *= quadrantCounts[x][y]
+		}
+	}
+
+	slog.Info("solved", "product", product)
+	out.Parsed("robots", len(robots))
+	out.Answer("product", product)
+
+	return nil
+}
+
+func readInput(input io.Reader, out *report.Report) ([]lib.Robot, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	values, err := lib.ReadInput(scanner)
+
+	return values, err //nolint:wrapcheck // Toy code
+}
@@ -0,0 +1,51 @@
+package lib
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"testing"
+
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readRobots(input string) ([]Robot, error) {
+	return ReadInput(bufio.NewScanner(strings.NewReader(input)))
+}
+
+// printRobots writes the robots back out in the puzzle format.
+func printRobots(robots []Robot) string {
+	var builder strings.Builder
+	for _, robot := range robots {
+		fmt.Fprintf(&builder, robotTemplate+"\n", robot.Pos.X, robot.Pos.Y, robot.Vel.X, robot.Vel.Y)
+	}
+	return builder.String()
+}
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("p=0,4 v=3,-3\np=6,3 v=-1,-3\np=10,3 v=-1,2\np=2,0 v=2,-1\n")
+	f.Add("\n  p=0,4\t v=3,-3  \n\n")
+	f.Add("p=-1,4 v=3,-3\n")
+	f.Add("p=0,4 v=3,-3 extra\n")
+	f.Add("p=0,4 v=3\n")
+	f.Add("p=0,4 v=99999999999999999999,1\n")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, input string) {
+		robots, err := readRobots(input)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+		for _, robot := range robots {
+			require.GreaterOrEqual(t, robot.Pos.X, int64(0))
+			require.GreaterOrEqual(t, robot.Pos.Y, int64(0))
+		}
+
+		reread, err := readRobots(printRobots(robots))
+		require.NoError(t, err)
+		assert.Equal(t, robots, reread)
+	})
+}
@@ -0,0 +1,157 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"common/command"
+	"common/logging"
+	"common/report"
+	"dayfourteen/b/lib"
+)
+
+type Args struct {
+	command.Flags
+
+	X                       int64 `arg:"-x, --x-dimension"   default:"101"     help:"X dimension of the board"`
+	Y                       int64 `arg:"-y, --y-dimension"   default:"103"     help:"Y dimension of the board"`
+	SecondsToFF             int64 `arg:"-s, --seconds"       default:"100"     help:"seconds to fast-forward"`
+	DisplayAfter            int64 `arg:"-d, --display-after" default:"-1"      help:"display board after this many seconds"`
+	MinQuadDisplayThreshold int   `arg:"-i, --min-threshold" default:"-1"      help:"display board if it has a quad count at or below this value"`
+	MaxQuadDisplayThreshold int   `arg:"-a, --max-threshold" default:"-1"      help:"display board if it has a quad count at or above this value"`
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	if args.X < 2 {
+		return fmt.Errorf("X dimension of board must be at least 2; got %d", args.X)
+	}
+	if args.Y < 2 {
+		return fmt.Errorf("Y dimension of board must be at least 2; got %d", args.Y)
+	}
+
+	dimensions := lib.Coord{X: args.X, Y: args.Y}
+
+	robots, err := readInput(input, out)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	slog.Debug("read robots", "robots", len(robots))
+
+	// Boards go to stderr alongside the log when stdout is reserved for the report.
+	display := io.Writer(os.Stdout)
+	if out.IsJSON() {
+		display = os.Stderr
+	}
+
+	if args.MaxQuadDisplayThreshold < 0 {
+		args.MaxQuadDisplayThreshold = len(robots)
+	}
+
+	if logging.DebugEnabled() {
+		displayAll(display, robots, dimensions)
+	}
+
+	// Advance all robots
+	midpoints := dimensions.Div(2)
+	overallMinQuadrantCount := len(robots)
+	overallMaxQuadrantCount := 0
+	for iSec := range args.SecondsToFF {
+		err = ctx.Err()
+		if err != nil {
+			return err //nolint:wrapcheck // Toy code
+		}
+
+		for iRobot := range robots {
+			robot := &robots[iRobot]
+			robot.Pos = robot.Pos.Add(robot.Vel).Add(dimensions).ModOther(dimensions)
+		}
+
+		quadrantCounts := genQuadrantCounts(robots, midpoints)
+
+		minQuadrantCount := len(robots)
+		maxQuadrantCount := 0
+		for x := range 2 {
+			for y := range 2 {
+				minQuadrantCount = min(minQuadrantCount, quadrantCounts[x][y])
+				maxQuadrantCount = max(maxQuadrantCount, quadrantCounts[x][y])
+			}
+		}
+		overallMinQuadrantCount = min(overallMinQuadrantCount, minQuadrantCount)
+		overallMaxQuadrantCount = max(overallMaxQuadrantCount, maxQuadrantCount)
+
+		slog.Debug("quadrant counts", "seconds", iSec+1, "counts", quadrantCounts)
+		if (iSec == args.DisplayAfter) || (minQuadrantCount <= args.MinQuadDisplayThreshold) || (maxQuadrantCount >= args.MaxQuadDisplayThreshold) {
+			displayAll(display, robots, dimensions)
+			slog.Info("displayed board", "seconds", iSec+1)
+		}
+	}
+
+	slog.Info("quadrant count range", "min", overallMinQuadrantCount, "max", overallMaxQuadrantCount)
+
+	quadrantCounts := genQuadrantCounts(robots, midpoints)
+
+	slog.Debug("quadrant counts", "counts", quadrantCounts)
+
+	product := int64(1)
+	for x := range 2 {
+		for y := range 2 {
+			product *= int64(quadrantCounts[x][y])
+		}
+	}
+
+	slog.Info("solved", "product", product)
+	out.Parsed("robots", len(robots))
+	out.Answer("product", product)
+
+	return nil
+}
+
+func genQuadrantCounts(robots []lib.Robot, midpoints lib.Coord) [2][2]int {
+	var quadrantCounts [2][2]int
+	divPoints := midpoints.Add(lib.Coord{X: 1, Y: 1})
+	for _, robot := range robots {
+		if robot.Pos.X == midpoints.X || robot.Pos.Y == midpoints.Y {
+			continue
+		}
+
+		quadrants := robot.Pos.DivOther(divPoints)
+		quadrantCounts[quadrants.X][quadrants.Y]++
+	}
+	return quadrantCounts
+}
+
+func displayAll(w io.Writer, robots []lib.Robot, dimensions lib.Coord) {
+	board := make([][]bool, dimensions.X)
+	for x := range board {
+		board[x] = make([]bool, dimensions.Y)
+	}
+
+	for _, robot := range robots {
+		board[robot.Pos.X][robot.Pos.Y] = true
+	}
+
+	for x := range board {
+		for y := range board[x] {
+			if board[x][y] {
+				fmt.Fprint(w, "#")
+			} else {
+				fmt.Fprint(w, ".")
+			}
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+func readInput(input io.Reader, out *report.Report) ([]lib.Robot, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	values, err := lib.ReadInput(scanner)
+
+	return values, err //nolint:wrapcheck // Toy code
+}
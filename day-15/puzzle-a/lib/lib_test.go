@@ -0,0 +1,74 @@
+package lib
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readGame(input string) (*Game, error) {
+	return ReadInput(bufio.NewScanner(strings.NewReader(input)))
+}
+
+// printGame writes the game back out in the puzzle format.
+func printGame(game *Game) string {
+	cellRunes := map[Cell]rune{Empty: '.', Box: 'O', Wall: '#'}
+	var builder strings.Builder
+	for iRow, row := range game.Board {
+		for iCol := 0; iCol < len(row); iCol++ {
+			if (Coord{Row: iRow, Col: iCol}) == game.Robot {
+				builder.WriteRune('@')
+			} else {
+				builder.WriteRune(cellRunes[row[iCol]])
+			}
+		}
+		builder.WriteByte('\n')
+	}
+	builder.WriteByte('\n')
+	for _, move := range game.Moves {
+		for char, dir := range DirectionsByRune {
+			if dir == move {
+				builder.WriteRune(char)
+			}
+		}
+	}
+	builder.WriteByte('\n')
+	return builder.String()
+}
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("########\n#..O.O.#\n##@.O..#\n#...O..#\n#.#.O..#\n#...O..#\n#......#\n########\n\n<^^>>>vv\n<v>>v<<\n")
+	f.Add("#####\n#.O@#\n#####\n")
+	f.Add("#####\n#.O.#\n#####\n\n<<\n")
+	f.Add("#####\n#@O@#\n#####\n\n<<\n")
+	f.Add("#####\n#.@#\n#####\n\n<<\n")
+	f.Add("#####\n#.X@#\n#####\n\n<<\n")
+	f.Add("###\n#@#\n###\n\n<x>\n")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, input string) {
+		game, err := readGame(input)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+		for _, row := range game.Board {
+			require.Len(t, row, len(game.Board[0]))
+		}
+		require.Less(t, game.Robot.Row, len(game.Board))
+		require.Less(t, game.Robot.Col, len(game.Board[0]))
+		assert.Equal(t, Empty, game.Board[game.Robot.Row][game.Robot.Col])
+		for iBox, box := range game.Boxes {
+			assert.Equal(t, iBox, game.BoxesByCoord[box])
+			assert.Equal(t, Box, game.Board[box.Row][box.Col])
+		}
+
+		reread, err := readGame(printGame(game))
+		require.NoError(t, err)
+		assert.Equal(t, game, reread)
+	})
+}
@@ -0,0 +1,132 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"dayfifteen/a/lib"
+
+	"github.com/samber/lo"
+)
+
+type Args struct {
+	command.Flags
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	game, err := readInput(input, out)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	if len(game.Board) < 1 {
+		return errors.New("board is empty")
+	}
+
+	dimensions := lib.Coord{Row: len(game.Board), Col: len(game.Board[0])}
+	slog.Debug("read game", "dimensions", dimensions, "boxes", len(game.Boxes), "robot", game.Robot, "moves", len(game.Moves))
+	out.Parsed("rows", dimensions.Row)
+	out.Parsed("cols", dimensions.Col)
+	out.Parsed("boxes", len(game.Boxes))
+	out.Parsed("moves", len(game.Moves))
+
+	for _, move := range game.Moves {
+		err = ctx.Err()
+		if err != nil {
+			return err //nolint:wrapcheck // Toy code
+		}
+
+		nextCoords := game.Robot.Add(move)
+		if !nextCoords.IsValid(dimensions) {
+			continue
+		}
+
+		nextCell := &game.Board[nextCoords.Row][nextCoords.Col]
+		if *nextCell == lib.Wall {
+			continue
+		}
+
+		if *nextCell == lib.Empty {
+			game.Robot = nextCoords
+			continue
+		}
+
+		pushDest, ok := evalPush(*game, dimensions, move)
+		if !ok {
+			continue
+		}
+
+		err := execPush(game, dimensions, move, *pushDest)
+		if err != nil {
+			log.Panic(err)
+		}
+	}
+
+	slog.Debug("final robot position", "robot", game.Robot)
+
+	totalScore := lo.Sum(lo.Map(game.Boxes, func(box lib.Coord, _ int) int {
+		return 100*box.Row + box.Col
+	}))
+	slog.Info("solved", "totalScore", totalScore)
+	out.Answer("totalScore", totalScore)
+
+	return nil
+}
+
+func execPush(game *lib.Game, dimensions lib.Coord, move lib.Coord, pushDest lib.Coord) error {
+	for {
+		curCell := &game.Board[pushDest.Row][pushDest.Col]
+		nextCoords := pushDest.Sub(move)
+		if !nextCoords.IsValid(dimensions) {
+			return fmt.Errorf("internal error: invalid coordinates %v while executing push", nextCoords)
+		}
+		nextCell := &game.Board[nextCoords.Row][nextCoords.Col]
+		switch *nextCell { //nolint:exhaustive // has default
+		case lib.Box:
+			*curCell = *nextCell
+			iBox := game.BoxesByCoord[nextCoords]
+			delete(game.BoxesByCoord, nextCoords)
+			game.Boxes[iBox] = pushDest
+			game.BoxesByCoord[pushDest] = iBox
+		case lib.Empty:
+			*curCell = *nextCell
+			game.Robot = pushDest
+			return nil
+		default:
+			return fmt.Errorf("internal error: unexpected cell type %v while executing push", *nextCell)
+		}
+		pushDest = nextCoords
+	}
+}
+
+func evalPush(game lib.Game, dimensions lib.Coord, move lib.Coord) (*lib.Coord, bool) {
+	for nextCoords := game.Robot.Add(move); nextCoords.IsValid(dimensions); nextCoords = nextCoords.Add(move) {
+		nextCell := &game.Board[nextCoords.Row][nextCoords.Col]
+		switch *nextCell {
+		case lib.Box:
+			continue
+		case lib.Wall:
+			return &nextCoords, false
+		case lib.Empty:
+			return &nextCoords, true
+		}
+	}
+
+	return nil, false
+}
+
+func readInput(input io.Reader, out *report.Report) (*lib.Game, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	values, err := lib.ReadInput(scanner)
+
+	return values, err //nolint:wrapcheck // Toy code
+}
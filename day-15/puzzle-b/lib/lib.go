@@ -2,8 +2,9 @@ package lib
 
 import (
 	"bufio"
-	"fmt"
 	"strings"
+
+	"common/parsing"
 )
 
 type Coord struct {
@@ -62,11 +63,21 @@ type Game struct {
 func ReadInput(scanner *bufio.Scanner) (*Game, error) {
 	game := &Game{}
 	game.BoxesByCoord = make(map[Coord]int)
+	lineNum := 0
+	mapWidth := -1
+	foundRobot := false
 	for scanner.Scan() {
+		lineNum++
 		line := scanner.Text()
 		if len(strings.TrimSpace(line)) < 1 {
 			break
 		}
+		if mapWidth < 0 {
+			mapWidth = len(line)
+		}
+		if len(line) != mapWidth {
+			return nil, parsing.Errorf(lineNum, min(len(line), mapWidth)+1, "", "expected %d map columns, got %d", mapWidth, len(line))
+		}
 
 		row := make([]Cell, len(line)*2)
 		for iCol, char := range line {
@@ -74,6 +85,10 @@ func ReadInput(scanner *bufio.Scanner) (*Game, error) {
 			coord := Coord{Row: len(game.Board), Col: realICol}
 			switch char {
 			case '@':
+				if foundRobot {
+					return nil, parsing.Errorf(lineNum, iCol+1, string(char), "multiple robots found")
+				}
+				foundRobot = true
 				game.Robot = coord
 				fallthrough
 			case '.':
@@ -89,23 +104,30 @@ func ReadInput(scanner *bufio.Scanner) (*Game, error) {
 				game.Boxes = append(game.Boxes, coord)
 
 			default:
-				return nil, fmt.Errorf("unrecognized cell character: `%c`", char)
+				return nil, parsing.Errorf(lineNum, iCol+1, string(char), "unrecognized cell character")
 			}
 		}
 
 		game.Board = append(game.Board, row)
 	}
+	if !foundRobot {
+		return nil, parsing.Errorf(max(lineNum, 1), 1, "", "no robot found on the map")
+	}
 
 	for scanner.Scan() {
+		lineNum++
 		line := scanner.Text()
-		for _, char := range line {
+		for iByte, char := range line {
 			dir, ok := DirectionsByRune[char]
 			if !ok {
-				return nil, fmt.Errorf("unrecognized direction character: `%c`", char)
+				return nil, parsing.Errorf(lineNum, iByte+1, string(char), "unrecognized direction character")
 			}
 			game.Moves = append(game.Moves, dir)
 		}
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
 
 	return game, nil
 }
@@ -2,9 +2,9 @@ package lib
 
 import (
 	"bufio"
-	"errors"
-	"fmt"
 	"strings"
+
+	"common/parsing"
 )
 
 type Coord struct {
@@ -141,19 +141,28 @@ var Moves = []Move{ //nolint:gochecknoglobals // Meant as a constant
 
 func ReadInput(scanner *bufio.Scanner) (*Maze, error) {
 	maze := &Maze{}
+	lineNum := 0
+	mazeWidth := -1
 	for scanner.Scan() {
+		lineNum++
 		line := scanner.Text()
 		if len(strings.TrimSpace(line)) < 1 {
 			break
 		}
+		if mazeWidth < 0 {
+			mazeWidth = len(line)
+		}
+		if len(line) != mazeWidth {
+			return nil, parsing.Errorf(lineNum, min(len(line), mazeWidth)+1, "", "expected %d columns, got %d", mazeWidth, len(line))
+		}
 
-		row := make([]Cell, len(line)*2)
+		row := make([]Cell, len(line))
 		for iCol, char := range line {
 			coord := Coord{Row: len(maze.Board), Col: iCol}
 			switch char {
 			case 'S':
 				if maze.Start != nil {
-					return nil, errors.New("multiple starting points found")
+					return nil, parsing.Errorf(lineNum, iCol+1, string(char), "multiple starting points found")
 				}
 				maze.Start = &Coord{Row: coord.Row, Col: coord.Col}
 				row[iCol] = Empty
@@ -163,7 +172,7 @@ func ReadInput(scanner *bufio.Scanner) (*Maze, error) {
 				row[iCol] = Wall
 			case 'E':
 				if maze.End != nil {
-					return nil, errors.New("multiple ending points found")
+					return nil, parsing.Errorf(lineNum, iCol+1, string(char), "multiple ending points found")
 				}
 				maze.End = &Coord{Row: coord.Row, Col: coord.Col}
 				maze.End.Row = coord.Row
@@ -171,7 +180,7 @@ func ReadInput(scanner *bufio.Scanner) (*Maze, error) {
 				row[iCol] = Empty
 
 			default:
-				return nil, fmt.Errorf("unrecognized cell character: `%c`", char)
+				return nil, parsing.Errorf(lineNum, iCol+1, string(char), "unrecognized cell character")
 			}
 		}
 
@@ -179,6 +188,16 @@ func ReadInput(scanner *bufio.Scanner) (*Maze, error) {
 		maze.Dimensions = Coord{Row: len(maze.Board), Col: len(row)}
 	}
 
+	if err := scanner.Err(); err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+	if maze.Start == nil {
+		return nil, parsing.Errorf(max(lineNum, 1), 1, "", "no starting point found")
+	}
+	if maze.End == nil {
+		return nil, parsing.Errorf(max(lineNum, 1), 1, "", "no ending point found")
+	}
+
 	maze.Cursor = Cursor{*maze.Start, StartDirection}
 	maze.Cost = 0
 	maze.Solved = false
@@ -0,0 +1,97 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"daysixteen/a/lib"
+
+	"github.com/samber/lo"
+)
+
+type Args struct {
+	command.Flags
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	maze, err := readInput(input, out)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	slog.Debug("read maze", "dimensions", maze.Dimensions, "start", *maze.Start, "end", *maze.End, "cursor", maze.Cursor)
+
+	bestPaths := make(map[lib.Cursor]lib.Cost)
+	cost, err := traverse(ctx, *maze, bestPaths)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("solved", "cost", cost)
+	out.Parsed("rows", maze.Dimensions.Row)
+	out.Parsed("cols", maze.Dimensions.Col)
+	out.Answer("cost", cost)
+
+	return nil
+}
+
+func traverse(ctx context.Context, state lib.Maze, bestPaths map[lib.Cursor]lib.Cost) (lib.Cost, error) {
+	cheapest, ok := bestPaths[state.Cursor]
+	if ok && cheapest <= state.Cost {
+		return -1, nil
+	}
+	err := ctx.Err()
+	if err != nil {
+		return 0, err //nolint:wrapcheck // Toy code
+	}
+
+	bestPaths[state.Cursor] = state.Cost
+	if state.Cursor.Coord == *state.End {
+		endCursors := lo.Map(lib.Directions, func(dir lib.Coord, _ int) lib.Cursor {
+			return lib.Cursor{Coord: state.Cursor.Coord, Dir: dir}
+		})
+		successfulEndCursors := lo.Filter(endCursors, func(c lib.Cursor, _ int) bool {
+			_, ok := bestPaths[c]
+			return ok
+		})
+		costs := lo.Map(successfulEndCursors, func(c lib.Cursor, _ int) lib.Cost {
+			return bestPaths[c]
+		})
+
+		return lo.Min(costs), nil
+	}
+
+	costs := make([]lib.Cost, 0, len(lib.Moves))
+	for _, move := range lib.Moves {
+		if !move.Precondition(state) {
+			continue
+		}
+
+		cost, err := traverse(ctx, move.Func(state), bestPaths)
+		if err != nil {
+			return 0, err
+		}
+		if cost > 0 {
+			costs = append(costs, cost)
+		}
+	}
+
+	if len(costs) < 1 {
+		return -1, nil
+	}
+
+	return lo.Min(costs), nil
+}
+
+func readInput(input io.Reader, out *report.Report) (*lib.Maze, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	values, err := lib.ReadInput(scanner)
+
+	return values, err //nolint:wrapcheck // Toy code
+}
@@ -0,0 +1,146 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"daysixteen/b/lib"
+
+	"github.com/hashicorp/go-set/v3"
+	pq "gopkg.in/dnaeon/go-priorityqueue.v1"
+)
+
+type Args struct {
+	command.Flags
+}
+
+const NothingFound = lib.Cost(-1)
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	maze, err := readInput(input, out)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	slog.Debug("read maze", "dimensions", maze.Dimensions, "start", *maze.Start, "end", *maze.End, "cursor", maze.Cursor)
+
+	bestPrice, nGoodSeats, err := traverse(ctx, *maze)
+	if err != nil {
+		return err
+	}
+	slog.Info("solved", "bestCost", bestPrice, "goodSeats", nGoodSeats)
+	out.Parsed("rows", maze.Dimensions.Row)
+	out.Parsed("cols", maze.Dimensions.Col)
+	out.Answer("bestCost", bestPrice)
+	out.Answer("goodSeats", nGoodSeats)
+
+	return nil
+}
+
+func traverse(ctx context.Context, wholeMaze lib.Maze) (lib.Cost, int, error) {
+	var prevsByEndCursor map[lib.Cursor]map[lib.Cursor][]lib.Cursor
+	var bestPrice lib.Cost
+	for _, dir := range lib.Directions {
+		actualEndCursor := lib.Cursor{Coord: *wholeMaze.End, Dir: dir}
+		revMaze := wholeMaze
+		revMaze.End, revMaze.Start = wholeMaze.Start, wholeMaze.End
+		revMaze.Cursor = actualEndCursor
+		revMaze.EndCursor = lib.Cursor{Coord: *revMaze.End, Dir: lib.StartDirection.Mul(-1)}
+		cost, prevs, err := doDijkstra(ctx, revMaze)
+		if err != nil {
+			return 0, 0, err
+		}
+		if cost == NothingFound {
+			continue
+		}
+		if len(prevsByEndCursor) < 1 || cost < bestPrice {
+			bestPrice = cost
+			prevsByEndCursor = make(map[lib.Cursor]map[lib.Cursor][]lib.Cursor)
+			prevsByEndCursor[revMaze.EndCursor] = prevs
+		}
+	}
+
+	goodSeats := set.New[lib.Coord](0)
+	for cursor, prevs := range prevsByEndCursor {
+		goodSeats.InsertSet(collectGoodSeats(prevs, cursor))
+	}
+
+	return bestPrice, goodSeats.Size(), nil
+}
+
+func doDijkstra(ctx context.Context, wholeMaze lib.Maze) (lib.Cost, map[lib.Cursor][]lib.Cursor, error) {
+	bestCostByCursor := make(map[lib.Cursor]lib.Cost)
+	bestCostByCursor[wholeMaze.Cursor] = 0
+	unvisitedQueue := pq.New[lib.Cursor, float64](pq.MinHeap)
+	unvisitedQueue.Put(wholeMaze.Cursor, 0)
+	removed := set.New[lib.Cursor](1)
+
+	prevs := make(map[lib.Cursor][]lib.Cursor)
+	for !unvisitedQueue.IsEmpty() {
+		err := ctx.Err()
+		if err != nil {
+			return 0, nil, err //nolint:wrapcheck // Toy code
+		}
+
+		item := unvisitedQueue.Get()
+		cursor, priority := item.Value, item.Priority
+		cost := lib.Cost(priority)
+		removed.Insert(cursor)
+		if cursor == wholeMaze.EndCursor {
+			return lib.Cost(priority), prevs, nil
+		}
+
+		for _, move := range lib.Moves {
+			if !move.Precondition(cursor, wholeMaze) {
+				continue
+			}
+
+			nextCursor, costIncr := move.Func(cursor)
+			if removed.Contains(nextCursor) {
+				continue
+			}
+
+			nextCost := cost + costIncr
+			if oldBest, ok := bestCostByCursor[nextCursor]; ok {
+				switch {
+				case nextCost < oldBest:
+					unvisitedQueue.Update(nextCursor, float64(nextCost))
+					prevs[nextCursor] = []lib.Cursor{cursor}
+				case nextCost == oldBest:
+					prevs[nextCursor] = append(prevs[nextCursor], cursor)
+				case nextCost > oldBest:
+					nextCost = oldBest
+				}
+			} else {
+				unvisitedQueue.Put(nextCursor, float64(nextCost))
+				prevs[nextCursor] = []lib.Cursor{cursor}
+			}
+			bestCostByCursor[nextCursor] = nextCost
+		}
+	}
+
+	return NothingFound, nil, nil
+}
+
+func collectGoodSeats(prevs map[lib.Cursor][]lib.Cursor, cursor lib.Cursor) *set.Set[lib.Coord] {
+	goodSeats := set.New[lib.Coord](1)
+	goodSeats.Insert(cursor.Coord)
+	for _, prevCursor := range prevs[cursor] {
+		goodSeats.InsertSet(collectGoodSeats(prevs, prevCursor))
+	}
+
+	return goodSeats
+}
+
+func readInput(input io.Reader, out *report.Report) (*lib.Maze, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	values, err := lib.ReadInput(scanner)
+
+	return values, err //nolint:wrapcheck // Toy code
+}
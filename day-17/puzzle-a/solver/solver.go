@@ -0,0 +1,121 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+//
+//nolint:mnd // Magic numbers all over the place
+package solver
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"strings"
+
+	"common/command"
+	"common/report"
+	"dayseventeen/a/lib"
+)
+
+type Args struct {
+	command.Flags
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	computer, program, err := readInput(input, out)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	slog.Debug("read program", "computer", *computer, "program", *program)
+
+	output, err := runProgram(ctx, *computer, *program)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("solved", "output", output)
+	out.Parsed("program", len(*program))
+	out.Answer("output", strings.TrimSuffix(output, ","))
+
+	return nil
+}
+
+func runProgram(ctx context.Context, computer lib.Computer, program lib.Program) (string, error) {
+	stringBuffer := strings.Builder{}
+	instructionPointer := 0
+	for instructionPointer < len(program) {
+		opCode := program[instructionPointer]
+		operand := program[instructionPointer+1]
+		switch opCode {
+		case lib.OpADiv, lib.OpBDiv, lib.OpCDiv:
+			numerator := computer.A
+			cOperand, err := comboOperand(computer, operand)
+			if err != nil {
+				return "", err
+			}
+			result := lib.Register(float64(numerator) / math.Pow(2, float64(cOperand)))
+
+			switch opCode {
+			case lib.OpADiv:
+				computer.A = result
+			case lib.OpBDiv:
+				computer.B = result
+			default:
+				computer.C = result
+			}
+		case lib.OpXOR:
+			computer.B = lib.Register(operand) ^ computer.B
+		case lib.OpStore:
+			cOperand, err := comboOperand(computer, operand)
+			if err != nil {
+				return "", err
+			}
+			computer.B = cOperand % 8
+		case lib.OpJumpNonZero:
+			if computer.A != 0 {
+				// A program may loop forever; only a jump back can make it.
+				err := ctx.Err()
+				if err != nil {
+					return "", err //nolint:wrapcheck // Toy code
+				}
+				instructionPointer = int(operand)
+				continue
+			}
+		case lib.OpRegisterXOR:
+			computer.B ^= computer.C
+		case lib.OpOutput:
+			cOperand, err := comboOperand(computer, operand)
+			if err != nil {
+				return "", err
+			}
+			stringBuffer.WriteString(fmt.Sprintf("%d,", cOperand%8))
+		}
+		instructionPointer += 2
+	}
+
+	return stringBuffer.String(), nil
+}
+
+func comboOperand(computer lib.Computer, operand lib.OpVal) (lib.Register, error) {
+	switch operand {
+	case 0, 1, 2, 3:
+		return lib.Register(operand), nil
+	case 4:
+		return computer.A, nil
+	case 5:
+		return computer.B, nil
+	case 6:
+		return computer.C, nil
+	default:
+		return lib.Register(0), fmt.Errorf("invalid operand: %v", operand)
+	}
+}
+
+func readInput(input io.Reader, out *report.Report) (*lib.Computer, *lib.Program, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	computer, program, err := lib.ReadInput(scanner)
+
+	return computer, program, err //nolint:wrapcheck // Toy code
+}
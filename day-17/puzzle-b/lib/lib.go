@@ -2,10 +2,10 @@ package lib
 
 import (
 	"bufio"
-	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
+
+	"common/parsing"
 )
 
 type Register int64
@@ -31,58 +31,59 @@ const (
 
 type Program []OpVal
 
+// inputPrefixes start the non-blank input lines, in order: the three registers and then the program.
+var inputPrefixes = []string{"Register A: ", "Register B: ", "Register C: ", "Program: "} //nolint:gochecknoglobals // Meant as a constant
+
 func ReadInput(scanner *bufio.Scanner) (*Computer, *Program, error) {
-	stringBuffer := strings.Builder{}
+	computer := Computer{}
+	program := Program{}
+	registers := []*Register{&computer.A, &computer.B, &computer.C}
+	iPrefix := 0
+	lineNum := 0
 	for scanner.Scan() {
+		lineNum++
 		line := scanner.Text()
-		stringBuffer.WriteString(line)
-		stringBuffer.WriteString("\n")
-	}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if iPrefix == len(inputPrefixes) {
+			return nil, nil, parsing.Errorf(lineNum, 1, line, "unexpected text after the program")
+		}
 
-	pattern := `Register A: (0|[1-9][0-9]*)
-Register B: (0|[1-9][0-9]*)
-Register C: (0|[1-9][0-9]*)
-\n*
-Program: ([0-7](,[0-7])*)
-`
-	re, err := regexp.Compile(pattern)
-	if err != nil {
-		return nil, nil, fmt.Errorf("internal error: failed to compile regex: %w", err)
-	}
+		prefix := inputPrefixes[iPrefix]
+		if !strings.HasPrefix(line, prefix) {
+			col := 0
+			for col < len(line) && col < len(prefix) && line[col] == prefix[col] {
+				col++
+			}
+			return nil, nil, parsing.Errorf(lineNum, col+1, line[col:], "expected %q", prefix[col:])
+		}
+		valueText := line[len(prefix):]
+		valueCol := len(prefix) + 1
 
-	match := re.FindStringSubmatch(stringBuffer.String())
-	computer := Computer{}
-	program := Program{}
-	if len(match) != 6 { //nolint:mnd // Regexp-dependent, check is for internal error.
-		return nil, nil, fmt.Errorf("internal error: unexpected number of matches: %v", len(match))
-	}
-	var regVal int
-	regVal, err = strconv.Atoi(match[1])
-	computer.A = Register(regVal)
-	if err != nil {
-		return nil, nil, fmt.Errorf("internal error: failed to convert button A row: %w (input string: `%v`)", err, match[1])
-	}
-	regVal, err = strconv.Atoi(match[2])
-	computer.B = Register(regVal)
-	if err != nil {
-		return nil, nil, fmt.Errorf("internal error: failed to convert button A col: %w (input string: `%v`)", err, match[2])
-	}
-	regVal, err = strconv.Atoi(match[3])
-	computer.C = Register(regVal)
-	if err != nil {
-		return nil, nil, fmt.Errorf("internal error: failed to convert button B row: %w (input string: `%v`)", err, match[3])
-	}
-	programString := match[4]
-	opCodeStrings := strings.Split(programString, ",")
-	for _, opCodeString := range opCodeStrings {
-		opCode, err := strconv.Atoi(opCodeString)
-		if err != nil {
-			return nil, nil, fmt.Errorf("internal error: failed to convert op code: %w (input string: `%v`)", err, opCodeString)
+		if iPrefix < len(registers) {
+			regVal, err := strconv.ParseInt(valueText, 10, 64)
+			if err != nil || regVal < 0 || (valueText[0] == '0' && len(valueText) > 1) {
+				return nil, nil, parsing.Errorf(lineNum, valueCol, valueText, "invalid register value")
+			}
+			*registers[iPrefix] = Register(regVal)
+			iPrefix++
+			continue
 		}
-		if opCode < 0 || opCode > 7 {
-			return nil, nil, fmt.Errorf("internal error: invalid op code: %v (input string: `%v`)", opCode, opCodeString)
+
+		for iOp, opCodeString := range strings.Split(valueText, ",") {
+			if len(opCodeString) != 1 || opCodeString[0] < '0' || opCodeString[0] > '7' {
+				return nil, nil, parsing.Errorf(lineNum, valueCol+2*iOp, opCodeString, "invalid op code")
+			}
+			program = append(program, OpVal(opCodeString[0]-'0'))
 		}
-		program = append(program, OpVal(opCode))
+		iPrefix++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err //nolint:wrapcheck // Toy code
+	}
+	if iPrefix < len(inputPrefixes) {
+		return nil, nil, parsing.Errorf(lineNum+1, 1, "", "unexpected end of input, expected %q", inputPrefixes[iPrefix])
 	}
 
 	return &computer, &program, nil
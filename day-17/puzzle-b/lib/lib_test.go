@@ -0,0 +1,55 @@
+package lib
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"testing"
+
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readComputer(input string) (*Computer, *Program, error) {
+	return ReadInput(bufio.NewScanner(strings.NewReader(input)))
+}
+
+// printComputer writes the registers and program back out in the puzzle format.
+func printComputer(computer *Computer, program *Program) string {
+	opCodes := make([]string, len(*program))
+	for iOp, op := range *program {
+		opCodes[iOp] = fmt.Sprint(op)
+	}
+	return fmt.Sprintf("%s%d\n%s%d\n%s%d\n\n%s%s\n",
+		inputPrefixes[0], computer.A, inputPrefixes[1], computer.B, inputPrefixes[2], computer.C,
+		inputPrefixes[3], strings.Join(opCodes, ","))
+}
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("Register A: 729\nRegister B: 0\nRegister C: 0\n\nProgram: 0,1,5,4,3,0\n")
+	f.Add("Register A: 2024\nRegister B: 0\nRegister C: 0\n\nProgram: 0,3,5,4,3,0\n")
+	f.Add("Register A: 1\nRegister B: 2\n")
+	f.Add("Register A: -1\nRegister B: 0\nRegister C: 0\n\nProgram: 0\n")
+	f.Add("Register A: 01\nRegister B: 0\nRegister C: 0\n\nProgram: 0\n")
+	f.Add("Register A: 1\nRegister B: 0\nRegister C: 0\n\nProgram: 0,8\n")
+	f.Add("Register A: 1\nRegister B: 0\nRegister C: 0\n\nProgram: 0,1\nextra\n")
+	f.Add("Register A: 99999999999999999999\n")
+	f.Add("Register B: 1\n")
+	f.Fuzz(func(t *testing.T, input string) {
+		computer, program, err := readComputer(input)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+		for _, op := range *program {
+			assert.True(t, op >= 0 && op <= 7)
+		}
+
+		rereadComputer, rereadProgram, err := readComputer(printComputer(computer, program))
+		require.NoError(t, err)
+		assert.Equal(t, computer, rereadComputer)
+		assert.Equal(t, program, rereadProgram)
+	})
+}
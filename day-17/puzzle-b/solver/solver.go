@@ -0,0 +1,157 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+//
+//nolint:mnd // Magic numbers all over the place
+package solver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+
+	"common/command"
+	"common/report"
+	"dayseventeen/b/lib"
+
+	"github.com/hashicorp/go-set/v3"
+	"github.com/samber/lo"
+)
+
+type Args struct {
+	command.Flags
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	computer, program, err := readInput(input, out)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	slog.Debug("read program", "computer", *computer, "program", *program)
+
+	solution, err := solve(ctx, *computer, *program)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("solved", "solution", solution)
+	out.Parsed("program", len(*program))
+	out.Answer("solution", solution)
+
+	return nil
+}
+
+func solve(ctx context.Context, computer lib.Computer, program lib.Program) (lib.Register, error) {
+	progLen := len(program)
+	output := program
+	outputLen := progLen
+
+	valuesToTest := set.New[lib.Register](1)
+	valuesToTest.Insert(0)
+	var previousGeneration *set.Set[lib.Register]
+	for outputIdx := outputLen - 1; outputIdx >= 0; outputIdx-- {
+		if valuesToTest.Empty() {
+			return -1, errors.New("ran out of possible states")
+		}
+
+		previousGeneration, valuesToTest = valuesToTest, set.New[lib.Register](0)
+		for value := range previousGeneration.Items() {
+			for variation := range 8 {
+				valueToCheck := 8*value + lib.Register(variation)
+				computer.A = valueToCheck
+				newOutput, err := runProgram(ctx, computer, output)
+				if err != nil {
+					return -1, err
+				}
+
+				if (*newOutput)[0] == output[outputIdx] {
+					valuesToTest.Insert(valueToCheck)
+				}
+			}
+		}
+	}
+
+	return lo.Min(valuesToTest.Slice()), nil
+}
+
+func runProgram(ctx context.Context, computer lib.Computer, program lib.Program) (*lib.Program, error) {
+	output := lib.Program{}
+	instructionPointer := 0
+	for instructionPointer < len(program) {
+		opCode := program[instructionPointer]
+		operand := program[instructionPointer+1]
+		switch opCode {
+		case lib.OpADiv, lib.OpBDiv, lib.OpCDiv:
+			numerator := computer.A
+			cOperand, err := comboOperand(computer, operand)
+			if err != nil {
+				return nil, err
+			}
+			result := lib.Register(float64(numerator) / math.Pow(2, float64(cOperand)))
+
+			switch opCode {
+			case lib.OpADiv:
+				computer.A = result
+			case lib.OpBDiv:
+				computer.B = result
+			default:
+				computer.C = result
+			}
+		case lib.OpXOR:
+			computer.B = lib.Register(operand) ^ computer.B
+		case lib.OpStore:
+			cOperand, err := comboOperand(computer, operand)
+			if err != nil {
+				return nil, err
+			}
+			computer.B = cOperand % 8
+		case lib.OpJumpNonZero:
+			if computer.A != 0 {
+				// A program may loop forever; only a jump back can make it.
+				err := ctx.Err()
+				if err != nil {
+					return nil, err //nolint:wrapcheck // Toy code
+				}
+				instructionPointer = int(operand)
+				continue
+			}
+		case lib.OpRegisterXOR:
+			computer.B ^= computer.C
+		case lib.OpOutput:
+			cOperand, err := comboOperand(computer, operand)
+			if err != nil {
+				return nil, err
+			}
+			output = append(output, lib.OpVal(cOperand%8)) //nolint:gosec // Truncation intentional
+		}
+		instructionPointer += 2
+	}
+
+	return &output, nil
+}
+
+func comboOperand(computer lib.Computer, operand lib.OpVal) (lib.Register, error) {
+	switch operand {
+	case 0, 1, 2, 3:
+		return lib.Register(operand), nil
+	case 4:
+		return computer.A, nil
+	case 5:
+		return computer.B, nil
+	case 6:
+		return computer.C, nil
+	default:
+		return lib.Register(0), fmt.Errorf("invalid operand: %v", operand)
+	}
+}
+
+func readInput(input io.Reader, out *report.Report) (*lib.Computer, *lib.Program, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	computer, program, err := lib.ReadInput(scanner)
+
+	return computer, program, err //nolint:wrapcheck // Toy code
+}
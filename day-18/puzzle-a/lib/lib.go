@@ -2,11 +2,10 @@ package lib
 
 import (
 	"bufio"
-	"fmt"
 	"strconv"
 	"strings"
 
-	"github.com/samber/lo"
+	"common/parsing"
 )
 
 type Coord struct {
@@ -68,7 +67,9 @@ func ReadInput(scanner *bufio.Scanner, dims Coord) (*Game, error) {
 	game.StateCache = make(map[int]Board)
 	game.StateCache[0] = Board{}
 	lineCounter := 0
+	lineNum := 0
 	for scanner.Scan() {
+		lineNum++
 		line := scanner.Text()
 		trimmed := strings.TrimSpace(line)
 		if len(trimmed) < 1 {
@@ -76,29 +77,31 @@ func ReadInput(scanner *bufio.Scanner, dims Coord) (*Game, error) {
 		}
 
 		lineCounter++
+		fieldCol := strings.Index(line, trimmed) + 1
 		fields := strings.Split(trimmed, ",")
 		if len(fields) != 2 {
-			return nil, fmt.Errorf("invalid input on line %d: `%s`", lineCounter, trimmed)
+			return nil, parsing.Errorf(lineNum, fieldCol, trimmed, "expected two comma-separated coordinates")
 		}
 
-		blockLoc := Coord{}
-		var err error
-		values := lo.Map(fields, func(str string, _ int) int {
-			value, innerErr := strconv.Atoi(str)
-			if innerErr != nil && err == nil {
-				err = innerErr
+		values := make([]int, len(fields))
+		for iField, str := range fields {
+			value, err := strconv.Atoi(str)
+			if err != nil {
+				return nil, parsing.Errorf(lineNum, fieldCol, str, "invalid coordinate")
 			}
-			return value
-		})
-		if err != nil {
-			return nil, err
+			values[iField] = value
+			fieldCol += len(str) + len(",")
 		}
-		blockLoc.Row, blockLoc.Col = values[0], values[1]
+
+		blockLoc := Coord{Row: values[0], Col: values[1]}
 		if !blockLoc.IsValid(dims) {
-			return nil, fmt.Errorf("invalid block location on line %d: %v", lineCounter, blockLoc)
+			return nil, parsing.Errorf(lineNum, strings.Index(line, trimmed)+1, trimmed, "block location outside the %dx%d board", dims.Row, dims.Col)
 		}
 		game.BlockSched[lineCounter] = blockLoc
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
 
 	game.Dims = dims
 
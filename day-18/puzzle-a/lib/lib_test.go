@@ -0,0 +1,53 @@
+package lib
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"testing"
+
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readGame(input string, dims Coord) (*Game, error) {
+	return ReadInput(bufio.NewScanner(strings.NewReader(input)), dims)
+}
+
+// printSchedule writes the block schedule back out in the puzzle format.
+func printSchedule(game *Game) string {
+	var builder strings.Builder
+	for iBlock := 1; iBlock <= len(game.BlockSched); iBlock++ {
+		fmt.Fprintf(&builder, "%d,%d\n", game.BlockSched[iBlock].Row, game.BlockSched[iBlock].Col)
+	}
+	return builder.String()
+}
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("5,4\n4,2\n4,5\n3,0\n2,1\n6,3\n2,4\n1,5\n0,6\n3,3\n", uint8(7), uint8(7))
+	f.Add("1,2,3\n", uint8(7), uint8(7))
+	f.Add("1\n", uint8(7), uint8(7))
+	f.Add("  1,2  \n\n3,x\n", uint8(7), uint8(7))
+	f.Add("7,0\n", uint8(7), uint8(7))
+	f.Add("-1,0\n", uint8(7), uint8(7))
+	f.Add("99999999999999999999,0\n", uint8(71), uint8(71))
+	f.Fuzz(func(t *testing.T, input string, rows uint8, cols uint8) {
+		dims := Coord{Row: int(rows), Col: int(cols)}
+		game, err := readGame(input, dims)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+		for iBlock := 1; iBlock <= len(game.BlockSched); iBlock++ {
+			require.Contains(t, game.BlockSched, iBlock)
+			assert.True(t, game.BlockSched[iBlock].IsValid(dims))
+		}
+
+		reread, err := readGame(printSchedule(game), dims)
+		require.NoError(t, err)
+		assert.Equal(t, game.BlockSched, reread.BlockSched)
+		assert.Equal(t, game.Dims, reread.Dims)
+	})
+}
@@ -0,0 +1,146 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"dayeighteen/b/lib"
+
+	"github.com/hashicorp/go-set/v3"
+	"github.com/samber/lo"
+	pq "gopkg.in/dnaeon/go-priorityqueue.v1"
+)
+
+type Args struct {
+	command.Flags
+
+	BoardDimRows int `arg:"-r,--board-dim-rows" default:"71" help:"board dimension rows"`
+	BoardDimCols int `arg:"-c,--board-dim-cols" default:"71" help:"board dimension cols"`
+	StartRow     int `arg:"-s,--start-row" default:"0" help:"starting row"`
+	StartCol     int `arg:"-t,--start-col" default:"0" help:"starting col"`
+	EndRow       int `arg:"-e,--end-row" default:"-1" help:"ending row"`
+	EndCol       int `arg:"-f,--end-col" default:"-1" help:"ending col"`
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	game, err := readInput(args, input, out)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	if args.EndRow < 0 {
+		args.EndRow = ((args.EndRow % args.BoardDimRows) + args.BoardDimRows) % args.BoardDimRows
+	}
+	if args.EndCol < 0 {
+		args.EndCol = ((args.EndCol % args.BoardDimCols) + args.BoardDimCols) % args.BoardDimCols
+	}
+
+	game.StartPos = lib.Coord{Row: args.StartRow, Col: args.StartCol}
+	game.EndPos = lib.Coord{Row: args.EndRow, Col: args.EndCol}
+
+	board := lib.Board(lo.Map(lo.Range(args.BoardDimRows), func(_, _ int) []bool {
+		row := make([]bool, args.BoardDimCols)
+		return row
+	}))
+	getBoardState := func(_ int) *lib.Board {
+		return &board
+	}
+
+	step := 0
+	for {
+		step++
+		loc, ok := game.BlockSched[step]
+		if !ok {
+			slog.Warn("no block scheduled", "step", step)
+		}
+		board[loc.Row][loc.Col] = true
+		pathLength, _, err := doDijkstra(ctx, *game, getBoardState)
+		if err != nil {
+			return err
+		}
+		if pathLength < 0 {
+			slog.Info("solved", "step", step, "lastBlock", fmt.Sprintf("%d,%d", loc.Row, loc.Col))
+			out.Answer("step", step)
+			out.Answer("lastBlock", fmt.Sprintf("%d,%d", loc.Row, loc.Col))
+			break
+		}
+	}
+	out.Parsed("blocks", len(game.BlockSched))
+
+	return nil
+}
+
+func doDijkstra(
+	ctx context.Context, game lib.Game, getBoardState func(int) *lib.Board,
+) (int, map[lib.Coord][]lib.Coord, error) {
+	bestCostByCoord := make(map[lib.Coord]int)
+	bestCostByCoord[game.StartPos] = 0
+	unvisitedQueue := pq.New[lib.Coord, float64](pq.MinHeap)
+	unvisitedQueue.Put(game.StartPos, 0)
+	removed := set.New[lib.Coord](1)
+
+	prevs := make(map[lib.Coord][]lib.Coord)
+	for !unvisitedQueue.IsEmpty() {
+		err := ctx.Err()
+		if err != nil {
+			return 0, nil, err //nolint:wrapcheck // Toy code
+		}
+
+		item := unvisitedQueue.Get()
+		coord, priority := item.Value, item.Priority
+		cost := int(priority)
+		removed.Insert(coord)
+		if coord == game.EndPos {
+			return int(priority), prevs, nil
+		}
+
+		for _, dir := range lib.Directions {
+			nextCoord := coord.Add(dir)
+			if !nextCoord.IsValid(game.Dims) {
+				continue
+			}
+
+			if removed.Contains(nextCoord) {
+				continue
+			}
+
+			boardState := getBoardState(cost)
+			if (*boardState)[nextCoord.Row][nextCoord.Col] {
+				continue
+			}
+
+			nextCost := cost + 1
+			if oldBest, ok := bestCostByCoord[nextCoord]; ok {
+				switch {
+				case nextCost < oldBest:
+					unvisitedQueue.Update(nextCoord, float64(nextCost))
+					prevs[nextCoord] = []lib.Coord{coord}
+				case nextCost == oldBest:
+					prevs[nextCoord] = append(prevs[nextCoord], coord)
+				case nextCost > oldBest:
+					nextCost = oldBest
+				}
+			} else {
+				unvisitedQueue.Put(nextCoord, float64(nextCost))
+				prevs[nextCoord] = []lib.Coord{coord}
+			}
+			bestCostByCoord[nextCoord] = nextCost
+		}
+	}
+
+	return -1, nil, nil
+}
+
+func readInput(args Args, input io.Reader, out *report.Report) (*lib.Game, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	game, err := lib.ReadInput(scanner, lib.Coord{Row: args.BoardDimRows, Col: args.BoardDimCols})
+
+	return game, err //nolint:wrapcheck // Toy code
+}
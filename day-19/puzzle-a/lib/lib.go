@@ -3,36 +3,198 @@ package lib
 import (
 	"bufio"
 	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
 	"strings"
+
+	"common/parsing"
 )
 
+// ReadInput reads the towel inventory, as a line of comma-separated stripe patterns, followed by the desired patterns,
+// one per line. Stripe colours are lowercase letters.
 func ReadInput(scanner *bufio.Scanner) ([]string, []string, error) {
 	var inventory []string
-	for scanner.Scan() && inventory == nil {
+	var patterns []string
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
 		line := scanner.Text()
 		trimmed := strings.TrimSpace(line)
 		if len(trimmed) < 1 {
 			continue
 		}
+		col := strings.Index(line, trimmed) + 1
+
+		if inventory != nil {
+			if iBad := badStripe(trimmed); iBad >= 0 {
+				return nil, nil, parsing.Errorf(lineNum, col+iBad, trimmed[iBad:iBad+1], "invalid stripe colour in pattern")
+			}
+			patterns = append(patterns, trimmed)
+			continue
+		}
 
-		towels := strings.Split(line, ", ")
-		inventory = append(inventory, towels...)
+		towels := strings.Split(trimmed, ", ")
+		for _, towel := range towels {
+			if len(towel) < 1 {
+				return nil, nil, parsing.Errorf(lineNum, col, "", "empty towel in inventory")
+			}
+			if iBad := badStripe(towel); iBad >= 0 {
+				return nil, nil, parsing.Errorf(lineNum, col+iBad, towel[iBad:iBad+1], "invalid stripe colour in towel %q", towel)
+			}
+			col += len(towel) + len(", ")
+		}
+		inventory = towels
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err //nolint:wrapcheck // Toy code
 	}
 
 	if inventory == nil {
-		return nil, nil, errors.New("no inventory found")
+		return nil, nil, parsing.Errorf(max(lineNum, 1), 1, "", "no inventory found")
 	}
 
-	var patterns []string
-	for scanner.Scan() {
-		line := scanner.Text()
-		trimmed := strings.TrimSpace(line)
-		if len(trimmed) < 1 {
-			continue
+	return inventory, patterns, nil
+}
+
+// badStripe returns the index of the first character of text that is not a stripe colour, or -1 if there is none.
+func badStripe(text string) int {
+	return strings.IndexFunc(text, func(r rune) bool {
+		return r < 'a' || r > 'z'
+	})
+}
+
+// Trie indexes the towels by their stripes, so that all towels matching at a given position of a pattern are found in
+// a single walk bounded by the longest towel.
+type Trie struct {
+	children map[byte]*Trie
+	isTowel  bool
+}
+
+func NewTrie(towels []string) *Trie {
+	trie := &Trie{children: make(map[byte]*Trie)}
+	for _, towel := range towels {
+		trie.Insert(towel)
+	}
+
+	return trie
+}
+
+func (t *Trie) Insert(towel string) {
+	node := t
+	for i := range len(towel) {
+		child, ok := node.children[towel[i]]
+		if !ok {
+			child = &Trie{children: make(map[byte]*Trie)}
+			node.children[towel[i]] = child
 		}
+		node = child
+	}
+	node.isTowel = len(towel) > 0
+}
 
-		patterns = append(patterns, trimmed)
+// MatchLengths returns the lengths of all towels that are a prefix of s, shortest first.
+func (t *Trie) MatchLengths(s string) []int {
+	var lengths []int
+	node := t
+	for i := range len(s) {
+		child, ok := node.children[s[i]]
+		if !ok {
+			break
+		}
+		node = child
+		if node.isTowel {
+			lengths = append(lengths, i+1)
+		}
 	}
 
-	return inventory, patterns, nil
+	return lengths
+}
+
+// ErrTooManyArrangements is returned when a number of arrangements does not fit in an int64, which takes a long pattern
+// and many towels.
+var ErrTooManyArrangements = errors.New("too many arrangements to count")
+
+// CountArrangements returns the number of ways the pattern can be laid out with the towels.
+func (t *Trie) CountArrangements(pattern string) (int64, error) {
+	counts, err := t.countsBySuffix(pattern)
+	if err != nil {
+		return 0, err
+	}
+
+	return counts[0], nil
+}
+
+// Arrangements returns up to limit arrangements of the pattern, each as the list of towels used in order.
+func (t *Trie) Arrangements(pattern string, limit int) ([][]string, error) {
+	counts, err := t.countsBySuffix(pattern)
+	if err != nil {
+		return nil, err
+	}
+	var arrangements [][]string
+	var walk func(pos int, prefix []string)
+	walk = func(pos int, prefix []string) {
+		if len(arrangements) >= limit {
+			return
+		}
+		if pos == len(pattern) {
+			arrangements = append(arrangements, slices.Clone(prefix))
+			return
+		}
+
+		for _, length := range t.MatchLengths(pattern[pos:]) {
+			if counts[pos+length] < 1 {
+				continue
+			}
+			walk(pos+length, append(prefix, pattern[pos:pos+length]))
+		}
+	}
+	walk(0, nil)
+
+	return arrangements, nil
+}
+
+// SampleArrangement returns an arrangement of the pattern drawn uniformly among all its arrangements, or nil if there
+// is none.
+func (t *Trie) SampleArrangement(pattern string, rng *rand.Rand) ([]string, error) {
+	counts, err := t.countsBySuffix(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if counts[0] < 1 {
+		return nil, nil
+	}
+
+	var arrangement []string
+	pos := 0
+	for pos < len(pattern) {
+		pick := rng.Int64N(counts[pos])
+		for _, length := range t.MatchLengths(pattern[pos:]) {
+			if pick < counts[pos+length] {
+				arrangement = append(arrangement, pattern[pos:pos+length])
+				pos += length
+				break
+			}
+			pick -= counts[pos+length]
+		}
+	}
+
+	return arrangement, nil
+}
+
+// countsBySuffix returns, for every position of the pattern, the number of arrangements of the pattern's suffix starting
+// there; the extra last entry stands for the empty suffix.
+func (t *Trie) countsBySuffix(pattern string) ([]int64, error) {
+	counts := make([]int64, len(pattern)+1)
+	counts[len(pattern)] = 1
+	for pos := len(pattern) - 1; pos >= 0; pos-- {
+		for _, length := range t.MatchLengths(pattern[pos:]) {
+			if counts[pos] > math.MaxInt64-counts[pos+length] {
+				return nil, ErrTooManyArrangements
+			}
+			counts[pos] += counts[pos+length]
+		}
+	}
+
+	return counts, nil
 }
@@ -0,0 +1,61 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"daynineteen/a/lib"
+)
+
+type Args struct {
+	command.Flags
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	inventory, patterns, err := readInput(input, out)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	trie := lib.NewTrie(inventory)
+	nSolvable := 0
+	for iPattern, pattern := range patterns {
+		err = ctx.Err()
+		if err != nil {
+			return err //nolint:wrapcheck // Toy code
+		}
+
+		// A pattern with too many arrangements to count still has some.
+		nArrangements, err := trie.CountArrangements(pattern)
+		if err != nil && !errors.Is(err, lib.ErrTooManyArrangements) {
+			return err //nolint:wrapcheck // Toy code
+		}
+		if err != nil || nArrangements > 0 {
+			nSolvable++
+			slog.Debug("pattern solvable", "pattern", iPattern)
+		} else {
+			slog.Debug("pattern not solvable", "pattern", iPattern)
+		}
+	}
+
+	slog.Info("solved", "solvablePatterns", nSolvable)
+	out.Parsed("towels", len(inventory))
+	out.Parsed("patterns", len(patterns))
+	out.Answer("solvablePatterns", nSolvable)
+
+	return nil
+}
+
+func readInput(input io.Reader, out *report.Report) ([]string, []string, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	inventory, patterns, err := lib.ReadInput(scanner)
+
+	return inventory, patterns, err //nolint:wrapcheck // Toy code
+}
@@ -0,0 +1,153 @@
+package lib
+
+import (
+	"bufio"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The towels of the puzzle statement.
+var exampleTowels = []string{"r", "wr", "b", "g", "bwu", "rb", "gb", "br"}
+
+func readOnsen(input string) ([]string, []string, error) {
+	return ReadInput(bufio.NewScanner(strings.NewReader(input)))
+}
+
+// printOnsen writes the inventory and the patterns back out in the puzzle format.
+func printOnsen(inventory, patterns []string) string {
+	return strings.Join(inventory, ", ") + "\n\n" + strings.Join(append(patterns, ""), "\n")
+}
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("r, wr, b, g, bwu, rb, gb, br\n\nbrwrr\nbggr\ngbbr\nrrbgbr\nubwu\nbwurrg\nbrgr\nbbrgwb\n")
+	f.Add("\n  r, wr\t\n\n  brwrr  \n")
+	f.Add("r, , b\n")
+	f.Add("r,wr\n")
+	f.Add("r, wr\n\nbrWrr\n")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, input string) {
+		inventory, patterns, err := readOnsen(input)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+		require.NotEmpty(t, inventory)
+
+		rereadInventory, rereadPatterns, err := readOnsen(printOnsen(inventory, patterns))
+		require.NoError(t, err)
+		assert.Equal(t, inventory, rereadInventory)
+		assert.Equal(t, patterns, rereadPatterns)
+	})
+}
+
+func TestMatchLengths(t *testing.T) {
+	tests := []struct {
+		name    string
+		towels  []string
+		s       string
+		lengths []int
+	}{
+		{name: "nested towels", towels: []string{"b", "br", "bru"}, s: "brush", lengths: []int{1, 2, 3}},
+		{name: "towel longer than the string", towels: []string{"b", "brush"}, s: "bru", lengths: []int{1}},
+		{name: "only a prefix of a towel", towels: []string{"brr"}, s: "br", lengths: nil},
+		{name: "no towel", towels: []string{"w", "u"}, s: "brush", lengths: nil},
+		{name: "empty string", towels: []string{"b"}, s: "", lengths: nil},
+		{name: "duplicate towels", towels: []string{"r", "r", "rb", "rb"}, s: "rbg", lengths: []int{1, 2}},
+		{name: "empty towel", towels: []string{"", "g"}, s: "gg", lengths: []int{1}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.lengths, NewTrie(test.towels).MatchLengths(test.s))
+		})
+	}
+}
+
+func TestCountArrangements(t *testing.T) {
+	tests := []struct {
+		pattern string
+		count   int64
+	}{
+		{pattern: "brwrr", count: 2},
+		{pattern: "bggr", count: 1},
+		{pattern: "gbbr", count: 4},
+		{pattern: "rrbgbr", count: 6},
+		{pattern: "ubwu", count: 0},
+		{pattern: "bwurrg", count: 1},
+		{pattern: "brgr", count: 2},
+		{pattern: "bbrgwb", count: 0},
+		{pattern: "", count: 1},
+	}
+	trie := NewTrie(exampleTowels)
+	for _, test := range tests {
+		count, err := trie.CountArrangements(test.pattern)
+		require.NoError(t, err, test.pattern)
+		assert.Equal(t, test.count, count, test.pattern)
+	}
+
+	// A towel listed twice is still one towel.
+	count, err := NewTrie(append(exampleTowels, "b", "br")).CountArrangements("brwrr")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	// Every split of a run of stripes into ones and twos: a Fibonacci number.
+	count, err = NewTrie([]string{"w", "ww"}).CountArrangements(strings.Repeat("w", 20))
+	require.NoError(t, err)
+	assert.Equal(t, int64(10946), count)
+}
+
+func TestTooManyArrangements(t *testing.T) {
+	// The 101st Fibonacci number is over 5e20, far more than an int64 holds.
+	trie := NewTrie([]string{"w", "ww"})
+	pattern := strings.Repeat("w", 100)
+
+	_, err := trie.CountArrangements(pattern)
+	require.ErrorIs(t, err, ErrTooManyArrangements)
+	_, err = trie.Arrangements(pattern, 1)
+	require.ErrorIs(t, err, ErrTooManyArrangements)
+	_, err = trie.SampleArrangement(pattern, rand.New(rand.NewPCG(1, 2)))
+	require.ErrorIs(t, err, ErrTooManyArrangements)
+
+	// The largest count that fits, the 92nd Fibonacci number, is still fine.
+	count, err := trie.CountArrangements(strings.Repeat("w", 91))
+	require.NoError(t, err)
+	assert.Equal(t, int64(7540113804746346429), count)
+}
+
+func TestArrangements(t *testing.T) {
+	trie := NewTrie(exampleTowels)
+	arrangements := func(pattern string, limit int) [][]string {
+		arrangements, err := trie.Arrangements(pattern, limit)
+		require.NoError(t, err, pattern)
+		return arrangements
+	}
+	assert.Equal(t, [][]string{{"b", "r", "wr", "r"}, {"br", "wr", "r"}}, arrangements("brwrr", 10))
+	assert.Equal(t, [][]string{{"b", "r", "wr", "r"}}, arrangements("brwrr", 1))
+	assert.Len(t, arrangements("rrbgbr", 10), 6)
+	assert.Empty(t, arrangements("ubwu", 10))
+}
+
+func TestSampleArrangement(t *testing.T) {
+	trie := NewTrie(exampleTowels)
+	rng := rand.New(rand.NewPCG(1, 2))
+	seen := make(map[string]int)
+	for range 600 {
+		arrangement, err := trie.SampleArrangement("rrbgbr", rng)
+		require.NoError(t, err)
+		assert.Equal(t, "rrbgbr", strings.Join(arrangement, ""))
+		seen[strings.Join(arrangement, " ")]++
+	}
+	// All 6 arrangements come up, each about a sixth of the time.
+	assert.Len(t, seen, 6)
+	for arrangement, count := range seen {
+		assert.InDelta(t, 100, count, 40, arrangement)
+	}
+
+	arrangement, err := trie.SampleArrangement("ubwu", rng)
+	require.NoError(t, err)
+	assert.Nil(t, arrangement)
+}
@@ -0,0 +1,90 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"strings"
+
+	"common/command"
+	"common/report"
+	"daynineteen/b/lib"
+)
+
+type Args struct {
+	command.Flags
+
+	Enumerate int    `arg:"-e,--enumerate" help:"log up to this many arrangements per pattern"`
+	Sample    int    `arg:"-s,--sample" help:"log this many uniformly sampled arrangements per pattern"`
+	Seed      uint64 `arg:"--seed" default:"1" help:"seed for sampling arrangements"`
+}
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	inventory, patterns, err := readInput(input, out)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	trie := lib.NewTrie(inventory)
+	rng := rand.New(rand.NewPCG(args.Seed, args.Seed)) //nolint:gosec // Not meant to be secure
+	nTotalSolutions := int64(0)
+	for iPattern, pattern := range patterns {
+		err = ctx.Err()
+		if err != nil {
+			return err //nolint:wrapcheck // Toy code
+		}
+
+		nSolutions, err := trie.CountArrangements(pattern)
+		if err != nil {
+			return fmt.Errorf("pattern %d: %w", iPattern+1, err)
+		}
+		slog.Debug("pattern", "pattern", iPattern, "solutions", nSolutions)
+		if nTotalSolutions > math.MaxInt64-nSolutions {
+			return lib.ErrTooManyArrangements
+		}
+		nTotalSolutions += nSolutions
+
+		arrangements, err := trie.Arrangements(pattern, args.Enumerate)
+		if err != nil {
+			return err //nolint:wrapcheck // Toy code
+		}
+		for _, arrangement := range arrangements {
+			slog.Info("arrangement", "pattern", iPattern, "towels", strings.Join(arrangement, " "))
+		}
+
+		for range args.Sample {
+			err = ctx.Err()
+			if err != nil {
+				return err //nolint:wrapcheck // Toy code
+			}
+			arrangement, err := trie.SampleArrangement(pattern, rng)
+			if err != nil {
+				return err //nolint:wrapcheck // Toy code
+			}
+			if arrangement == nil {
+				break
+			}
+			slog.Info("sampled arrangement", "pattern", iPattern, "towels", strings.Join(arrangement, " "))
+		}
+	}
+
+	slog.Info("solved", "arrangements", nTotalSolutions)
+	out.Parsed("towels", len(inventory))
+	out.Parsed("patterns", len(patterns))
+	out.Answer("arrangements", nTotalSolutions)
+
+	return nil
+}
+
+func readInput(input io.Reader, out *report.Report) ([]string, []string, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	inventory, patterns, err := lib.ReadInput(scanner)
+
+	return inventory, patterns, err //nolint:wrapcheck // Toy code
+}
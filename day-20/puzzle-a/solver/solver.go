@@ -0,0 +1,203 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"daytwenty/a/lib"
+
+	"github.com/hashicorp/go-set/v3"
+	pq "gopkg.in/dnaeon/go-priorityqueue.v1"
+)
+
+type Args struct {
+	command.Flags
+
+	CheatThreshold int `arg:"-n,--cheat-threshold" default:"100" help:"cheat threshold"`
+}
+
+const NothingFound = lib.Cost(-1)
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	maze, err := readInput(input, out)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	slog.Debug("read maze", "dimensions", maze.Dimensions, "start", *maze.Start, "end", *maze.End, "pos", maze.Pos)
+
+	noCheatMaze := *maze
+	noCheatMaze.HasCheated = true
+	bestNoCheatingPrice, prevs, err := doDijkstra(ctx, noCheatMaze)
+	if err != nil {
+		return err
+	}
+	if bestNoCheatingPrice == NothingFound {
+		return errors.New("ending coord not reachable from starting coord")
+	}
+	slog.Info("best price without cheating", "price", bestNoCheatingPrice)
+	endState := lib.State{
+		Pos:        *maze.End,
+		HasCheated: true,
+	}
+	nNoCheatingPaths, _ := collectPaths(*maze, endState, prevs)
+	slog.Debug("paths without cheating", "paths", nNoCheatingPaths)
+
+	maze.BlockedCheats = set.New[lib.Coord](0)
+	nTotalCheatPaths := 0
+	cheatCost := lib.Cost(args.CheatThreshold)
+	for cheatCost < bestNoCheatingPrice {
+		cheatMaze := *maze
+		cheatMaze.HasCheated = false
+		cheatMaze.CheatCost = lib.Cost(args.CheatThreshold)
+		bestPenalizedPrice, prevs, err := doDijkstra(ctx, cheatMaze)
+		if err != nil {
+			return err
+		}
+		switch {
+		case bestPenalizedPrice > bestNoCheatingPrice:
+			log.Panicf("bestPenalizedPrice=%d > bestNoCheatingPrice=%d", bestPenalizedPrice, bestNoCheatingPrice)
+		case bestPenalizedPrice == bestNoCheatingPrice:
+			cheatCost++
+		case bestPenalizedPrice < bestNoCheatingPrice:
+			nNewPaths, newBlockedCheats := collectPaths(*maze, endState, prevs)
+			for _, pos := range newBlockedCheats {
+				maze.BlockedCheats.Insert(pos)
+			}
+			nTotalCheatPaths += nNewPaths
+			cheatCost = bestPenalizedPrice
+			slog.Debug("new paths", "paths", nNewPaths, "cheatCost", cheatCost)
+		}
+	}
+
+	slog.Info("solved", "cheatPaths", nTotalCheatPaths)
+	out.Parsed("rows", maze.Dimensions.Row)
+	out.Parsed("cols", maze.Dimensions.Col)
+	out.Answer("bestNoCheatingPrice", bestNoCheatingPrice)
+	out.Answer("cheatPaths", nTotalCheatPaths)
+
+	return nil
+}
+
+func collectPaths(maze lib.Maze, state lib.State, prevs map[lib.State][]lib.State) (int, []lib.Coord) {
+	if _, ok := prevs[state]; !ok {
+		return 1, nil
+	}
+
+	nPaths := 0
+	var cheatList []lib.Coord
+	isCheat := maze.Board[state.Pos.Row][state.Pos.Col] == lib.Wall
+	cheatList = make([]lib.Coord, 0)
+	if isCheat {
+		cheatList = append(cheatList, state.Pos)
+	}
+
+	for _, prevState := range prevs[state] {
+		nNewPaths, newCheats := collectPaths(maze, prevState, prevs)
+		nPaths += nNewPaths
+		cheatList = append(cheatList, newCheats...)
+	}
+	return nPaths, cheatList
+}
+
+func doDijkstra(ctx context.Context, maze lib.Maze) (lib.Cost, map[lib.State][]lib.State, error) {
+	initState := lib.State{
+		Pos:        *maze.Start,
+		HasCheated: maze.HasCheated,
+	}
+	bestCostByState := make(map[lib.State]lib.Cost)
+	bestCostByState[initState] = 0
+	unvisitedQueue := pq.New[lib.State, float64](pq.MinHeap)
+	unvisitedQueue.Put(initState, 0)
+	var removed *set.Set[lib.State]
+	if maze.BlockedCheats != nil {
+		removed = set.New[lib.State](maze.BlockedCheats.Size())
+		for pos := range maze.BlockedCheats.Items() {
+			removed.Insert(lib.State{
+				Pos:        pos,
+				HasCheated: true,
+			})
+		}
+	} else {
+		removed = set.New[lib.State](0)
+	}
+
+	prevs := make(map[lib.State][]lib.State)
+	for !unvisitedQueue.IsEmpty() {
+		err := ctx.Err()
+		if err != nil {
+			return 0, nil, err //nolint:wrapcheck // Toy code
+		}
+
+		item := unvisitedQueue.Get()
+		state, priority := item.Value, item.Priority
+		cost := lib.Cost(priority)
+		removed.Insert(state)
+		if state.Pos == *maze.End {
+			return lib.Cost(priority), prevs, nil
+		}
+
+		for _, move := range lib.Moves {
+			maze.Pos = state.Pos
+			maze.HasCheated = state.HasCheated
+			if !move.Precondition(maze) {
+				continue
+			}
+
+			nextPos := move.Func(state.Pos)
+			nextIsWall := maze.Board[nextPos.Row][nextPos.Col] == lib.Wall
+			if nextIsWall && state.HasCheated {
+				continue
+			}
+
+			nextState := lib.State{
+				Pos:        nextPos,
+				HasCheated: state.HasCheated || nextIsWall,
+			}
+
+			if removed.Contains(nextState) {
+				continue
+			}
+
+			var nextCost lib.Cost
+			if nextIsWall {
+				nextCost = cost + maze.CheatCost
+			} else {
+				nextCost = cost + 1
+			}
+
+			if oldBest, ok := bestCostByState[nextState]; ok {
+				switch {
+				case nextCost < oldBest:
+					unvisitedQueue.Update(nextState, float64(nextCost))
+					prevs[nextState] = []lib.State{state}
+				case nextCost == oldBest:
+					prevs[nextState] = append(prevs[nextState], state)
+				case nextCost > oldBest:
+					nextCost = oldBest
+				}
+			} else {
+				unvisitedQueue.Put(nextState, float64(nextCost))
+				prevs[nextState] = []lib.State{state}
+			}
+			bestCostByState[nextState] = nextCost
+		}
+	}
+
+	return NothingFound, nil, nil
+}
+
+func readInput(input io.Reader, out *report.Report) (*lib.Maze, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	maze, err := lib.ReadInput(scanner)
+
+	return maze, err //nolint:wrapcheck // Toy code
+}
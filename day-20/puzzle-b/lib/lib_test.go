@@ -0,0 +1,73 @@
+package lib
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"common/parsing/parsingtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readMaze(input string) (*Maze, error) {
+	return ReadInput(bufio.NewScanner(strings.NewReader(input)))
+}
+
+// printMaze writes the maze back out in the puzzle format.
+func printMaze(maze *Maze) string {
+	var builder strings.Builder
+	for iRow, row := range maze.Board {
+		for iCol, cell := range row {
+			coord := Coord{Row: iRow, Col: iCol}
+			switch {
+			case coord == *maze.Start:
+				builder.WriteByte('S')
+			case coord == *maze.End:
+				builder.WriteByte('E')
+			case cell == Wall:
+				builder.WriteByte('#')
+			default:
+				builder.WriteByte('.')
+			}
+		}
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("###############\n#...#...#.....#\n#.#.#.#.#.###.#\n#S#...#.#.#...#\n#######.#.#.###\n#######.#.#...#\n" +
+		"#######.#.###.#\n###..E#...#...#\n###.#######.###\n#...###...#...#\n#.#####.#.###.#\n#.#...#.#.#...#\n" +
+		"#.#.#.#.#.#.###\n#...#...#...###\n###############\n")
+	f.Add("#####\n#S.E#\n#####\n")
+	f.Add("#####\n#S.E#\n####\n")
+	f.Add("#####\n#S.S#\n#E###\n")
+	f.Add("#####\n#S.E#\n#E###\n")
+	f.Add("#####\n#S..#\n#####\n")
+	f.Add("#####\n#..E#\n#####\n")
+	f.Add("#####\n#SxE#\n#####\n")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, input string) {
+		maze, err := readMaze(input)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+		require.Equal(t, Coord{Row: len(maze.Board), Col: len(maze.Board[0])}, maze.Dimensions)
+		for _, row := range maze.Board {
+			require.Len(t, row, maze.Dimensions.Col)
+		}
+		for _, point := range []*Coord{maze.Start, maze.End} {
+			require.True(t, point.IsValid(maze.Dimensions))
+			assert.Equal(t, Empty, maze.Board[point.Row][point.Col])
+		}
+
+		reread, err := readMaze(printMaze(maze))
+		require.NoError(t, err)
+		assert.Equal(t, maze.Board, reread.Board)
+		assert.Equal(t, maze.Start, reread.Start)
+		assert.Equal(t, maze.End, reread.End)
+	})
+}
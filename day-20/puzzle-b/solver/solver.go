@@ -0,0 +1,193 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+
+	"common/command"
+	"common/report"
+	"daytwenty/b/lib"
+
+	"github.com/hashicorp/go-set/v3"
+	"github.com/samber/lo"
+	pq "gopkg.in/dnaeon/go-priorityqueue.v1"
+)
+
+type Args struct {
+	command.Flags
+
+	DepthOfCheat            int `arg:"-d,--depth" default:"20" help:"depth of cheat window"`
+	ThresholdForImprovement int `arg:"-t,--threshold" default:"100" help:"threshold of improvement to consider"`
+}
+
+const (
+	nothingFound = lib.Cost(-1)
+)
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	maze, err := readInput(input, out)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	slog.Debug("read maze", "dimensions", maze.Dimensions, "start", *maze.Start, "end", *maze.End)
+
+	bestNoCheatingPrice, fwdBoard, err := doDijkstra(ctx, *maze)
+	if err != nil {
+		return err
+	}
+	if bestNoCheatingPrice == nothingFound {
+		return errors.New("ending coord not reachable from starting coord")
+	}
+	slog.Info("best price without cheating", "price", bestNoCheatingPrice)
+
+	revMaze := *maze
+	revMaze.Start, revMaze.End = revMaze.End, revMaze.Start
+	_, revBoard, err := doDijkstra(ctx, revMaze)
+	if err != nil {
+		return err
+	}
+
+	improverCounts, err := findCheats(
+		ctx, *maze, fwdBoard, revBoard, bestNoCheatingPrice, args.DepthOfCheat, lib.Cost(args.ThresholdForImprovement))
+	if err != nil {
+		return err
+	}
+	nImprover := lo.Sum(lo.Values(improverCounts))
+	slog.Info("solved", "improvers", nImprover)
+	out.Parsed("rows", maze.Dimensions.Row)
+	out.Parsed("cols", maze.Dimensions.Col)
+	out.Answer("bestNoCheatingPrice", bestNoCheatingPrice)
+	out.Answer("improvers", nImprover)
+
+	return nil
+}
+
+// findCheats considers every cheat from a cell reachable from the start to a cell from which the end is reachable,
+// within depthOfCheat steps. The cost of the cheating route is the forward distance to the cheat's start, plus the
+// cheat's length, plus the reverse distance from the cheat's end, so any maze shape is handled, not just single tracks.
+func findCheats(
+	ctx context.Context,
+	maze lib.Maze,
+	fwdBoard, revBoard [][]lib.Cost,
+	bestNoCheatingPrice lib.Cost,
+	depthOfCheat int,
+	thresholdForImprovement lib.Cost,
+) (map[lib.Cost]int, error) {
+	improverCounts := make(map[lib.Cost]int)
+	for iRow := range maze.Dimensions.Row {
+		err := ctx.Err()
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Toy code
+		}
+
+		for iCol := range maze.Dimensions.Col {
+			fwdCell := fwdBoard[iRow][iCol]
+			if fwdCell == nothingFound {
+				continue
+			}
+
+			pos := lib.Coord{Row: iRow, Col: iCol}
+			for dRow := -depthOfCheat; dRow <= depthOfCheat; dRow++ {
+				colDepth := depthOfCheat - lib.Abs(dRow)
+				for dCol := -colDepth; dCol <= colDepth; dCol++ {
+					cheatEnd := lib.Coord{Row: pos.Row + dRow, Col: pos.Col + dCol}
+					if !cheatEnd.IsValid(maze.Dimensions) {
+						continue
+					}
+
+					revCell := revBoard[cheatEnd.Row][cheatEnd.Col]
+					if revCell == nothingFound {
+						continue
+					}
+
+					cheatPrice := fwdCell + lib.Cost(lib.Distance(pos, cheatEnd)) + revCell
+					improvement := bestNoCheatingPrice - cheatPrice
+					if improvement < thresholdForImprovement {
+						continue
+					}
+
+					improverCounts[improvement]++
+				}
+			}
+		}
+	}
+
+	return improverCounts, nil
+}
+
+// doDijkstra returns the best cost from the maze's start to its end, along with the best cost to every cell of the
+// board; walls and unreachable cells are marked with nothingFound.
+func doDijkstra(ctx context.Context, maze lib.Maze) (lib.Cost, [][]lib.Cost, error) {
+	initState := lib.State{
+		Pos: *maze.Start,
+	}
+
+	bestCostByState := make(map[lib.State]lib.Cost)
+	bestCostByState[initState] = 0
+	unvisitedQueue := pq.New[lib.State, float64](pq.MinHeap)
+	unvisitedQueue.Put(initState, 0)
+	removed := set.New[lib.State](0)
+	for !unvisitedQueue.IsEmpty() {
+		err := ctx.Err()
+		if err != nil {
+			return 0, nil, err //nolint:wrapcheck // Toy code
+		}
+
+		item := unvisitedQueue.Get()
+		state, priority := item.Value, item.Priority
+		cost := lib.Cost(priority)
+
+		removed.Insert(state)
+		for _, move := range maze.Moves {
+			if !move.Precondition(maze, state) {
+				continue
+			}
+
+			nextState, costIncrease := move.Func(state)
+			if removed.Contains(nextState) {
+				continue
+			}
+
+			nextCost := cost + costIncrease
+			if oldBest, ok := bestCostByState[nextState]; ok {
+				if nextCost < oldBest {
+					unvisitedQueue.Update(nextState, float64(nextCost))
+				} else {
+					nextCost = oldBest
+				}
+			} else {
+				unvisitedQueue.Put(nextState, float64(nextCost))
+			}
+			bestCostByState[nextState] = nextCost
+		}
+	}
+
+	dijkstraBoard := make([][]lib.Cost, maze.Dimensions.Row)
+	for iRow := range maze.Dimensions.Row {
+		dijkstraBoard[iRow] = make([]lib.Cost, maze.Dimensions.Col)
+		for iCol := range maze.Dimensions.Col {
+			state := lib.State{Pos: lib.Coord{Row: iRow, Col: iCol}}
+			depth, ok := bestCostByState[state]
+			if !ok || maze.Board[iRow][iCol] == lib.Wall {
+				depth = nothingFound
+			}
+
+			dijkstraBoard[iRow][iCol] = depth
+		}
+	}
+
+	return dijkstraBoard[maze.End.Row][maze.End.Col], dijkstraBoard, nil
+}
+
+func readInput(input io.Reader, out *report.Report) (*lib.Maze, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	maze, err := lib.ReadInput(scanner)
+
+	return maze, err //nolint:wrapcheck // Toy code
+}
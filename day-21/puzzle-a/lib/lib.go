@@ -2,11 +2,11 @@ package lib
 
 import (
 	"bufio"
-	"fmt"
-	"regexp"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
-	"github.com/samber/lo"
+	"common/parsing"
 )
 
 const (
@@ -87,36 +87,253 @@ var ActionPadLayout = [][]int{ //nolint:gochecknoglobals // Meant as a constant
 	{MoveLeft, MoveDown, MoveRight},
 }
 
-type NumPadCode []int
+const (
+	DefaultNumIntermediateKeypads = 2
+	layoutGapRune                 = '.'
+)
+
+// KeypadChain describes the num pad at the end of the chain, the action pad operated by every robot (and by the
+// human at the start of the chain), and how many robot-operated action pads sit in between.
+type KeypadChain struct {
+	NumPadLayout           [][]int
+	NumPadKeyByRune        map[rune]int
+	NumPadStartKey         int
+	ActionPadLayout        [][]int
+	NumIntermediateKeypads int
+}
 
-func ReadInput(scanner *bufio.Scanner) ([]NumPadCode, error) {
-	pattern := `[0-9]+A`
-	re, err := regexp.Compile(pattern)
-	if err != nil {
-		return nil, fmt.Errorf("internal error: failed to compile regex: %w", err)
+func DefaultKeypadChain() *KeypadChain {
+	return &KeypadChain{
+		NumPadLayout:           NumPadLayout,
+		NumPadKeyByRune:        NumPadKeyByRune,
+		NumPadStartKey:         NumPadKeyA,
+		ActionPadLayout:        ActionPadLayout,
+		NumIntermediateKeypads: DefaultNumIntermediateKeypads,
 	}
+}
 
-	var numPadCodes []NumPadCode
+// ReadKeypadChain reads a layout file made of `depth N` and `start X` directives, and of `numpad` and `actionpad`
+// sections whose following lines (up to the next blank line) draw the pad, with `.` or ` ` marking gaps. Lines starting
+// with `#` are comments, inside sections too. Every key of a pad must be reachable from the others. Sections and
+// directives that are left out fall back to the defaults.
+func ReadKeypadChain(scanner *bufio.Scanner) (*KeypadChain, error) {
+	chain := DefaultKeypadChain()
+	var numPadRows, actionPadRows []padRow
+	var section *[]padRow
+	var numPadLine, actionPadLine int
+	startRune := 'A'
+	startLine, startCol := 1, 1
 	iLine := 0
 	for scanner.Scan() {
 		line := scanner.Text()
 		iLine++
 		trimmedLine := strings.TrimSpace(line)
-		match := re.FindString(trimmedLine)
-		if len(match) == 0 {
+		if section != nil {
+			switch {
+			case len(trimmedLine) < 1:
+				section = nil
+			case !strings.HasPrefix(trimmedLine, "#"):
+				*section = append(*section, padRow{Text: strings.TrimRight(line, " \t"), Line: iLine})
+			}
+			continue
+		}
+
+		if len(trimmedLine) < 1 || strings.HasPrefix(trimmedLine, "#") {
 			continue
 		}
 
-		numPadCode := lo.Map([]rune(match), func(item rune, _ int) int {
-			return lo.ValueOr(NumPadKeyByRune, item, InvalidNumPadKey)
-		})
+		lineCol := strings.Index(line, trimmedLine) + 1
+		fields := strings.Fields(trimmedLine)
+		switch {
+		case fields[0] == "numpad" && len(fields) == 1:
+			numPadRows = nil
+			numPadLine = iLine
+			section = &numPadRows
+		case fields[0] == "actionpad" && len(fields) == 1:
+			actionPadRows = nil
+			actionPadLine = iLine
+			section = &actionPadRows
+		case fields[0] == "depth" && len(fields) == 2:
+			depth, err := strconv.Atoi(fields[1])
+			if err != nil || depth < 0 {
+				return nil, parsing.Errorf(iLine, strings.LastIndex(line, fields[1])+1, fields[1], "invalid depth")
+			}
+			chain.NumIntermediateKeypads = depth
+		case fields[0] == "start" && len(fields) == 2 && utf8.RuneCountInString(fields[1]) == 1:
+			startRune, _ = utf8.DecodeRuneInString(fields[1])
+			startLine, startCol = iLine, strings.LastIndex(line, fields[1])+1
+		default:
+			return nil, parsing.Errorf(iLine, lineCol, trimmedLine, "unrecognized layout line")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+
+	if numPadRows != nil {
+		numPadLayout, numPadKeyByRune, err := parseNumPadRows(numPadRows)
+		if err != nil {
+			return nil, err
+		}
+		chain.NumPadLayout, chain.NumPadKeyByRune = numPadLayout, numPadKeyByRune
+		if len(chain.NumPadKeyByRune) < 1 {
+			return nil, parsing.Errorf(numPadLine, 1, "numpad", "num pad layout has no keys")
+		}
+		err = checkConnected(numPadRows, numPadLayout, InvalidNumPadKey, "num pad")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	startKey, ok := chain.NumPadKeyByRune[startRune]
+	if !ok {
+		return nil, parsing.Errorf(startLine, startCol, string(startRune), "start key not found on num pad")
+	}
+	chain.NumPadStartKey = startKey
+
+	if actionPadRows != nil {
+		actionPadLayout, err := parseActionPadRows(actionPadRows, actionPadLine)
+		if err != nil {
+			return nil, err
+		}
+		err = checkConnected(actionPadRows, actionPadLayout, InvalidAction, "action pad")
+		if err != nil {
+			return nil, err
+		}
+		chain.ActionPadLayout = actionPadLayout
+	}
+
+	return chain, nil
+}
+
+// padRow is a row of a pad section, along with the line it is on.
+type padRow struct {
+	Text string
+	Line int
+}
+
+// parseNumPadRows parses the rows of a num pad section. Keys are numbered in reading order.
+func parseNumPadRows(rows []padRow) ([][]int, map[rune]int, error) {
+	keyByRune := make(map[rune]int)
+	layout := make([][]int, len(rows))
+	for iRow, row := range rows {
+		for iByte, r := range row.Text {
+			key := InvalidNumPadKey
+			if r != layoutGapRune && r != ' ' {
+				if _, found := keyByRune[r]; found {
+					return nil, nil, parsing.Errorf(row.Line, iByte+1, string(r), "duplicate num pad key")
+				}
+				key = len(keyByRune) + 1
+				keyByRune[r] = key
+			}
+			layout[iRow] = append(layout[iRow], key)
+		}
+	}
+
+	return layout, keyByRune, nil
+}
+
+// parseActionPadRows parses the rows of an action pad section, which starts on the given line.
+func parseActionPadRows(rows []padRow, sectionLine int) ([][]int, error) {
+	seen := make(map[int]bool)
+	layout := make([][]int, len(rows))
+	for iRow, row := range rows {
+		for iByte, r := range row.Text {
+			action := InvalidAction
+			if r != layoutGapRune && r != ' ' {
+				var ok bool
+				action, ok = ActionByRune[r]
+				if !ok {
+					return nil, parsing.Errorf(row.Line, iByte+1, string(r), "unrecognized action pad key")
+				}
+				if seen[action] {
+					return nil, parsing.Errorf(row.Line, iByte+1, string(r), "duplicate action pad key")
+				}
+				seen[action] = true
+			}
+			layout[iRow] = append(layout[iRow], action)
+		}
+	}
+
+	if len(seen) != len(ActionByRune) {
+		return nil, parsing.Errorf(sectionLine, 1, "actionpad", "action pad must have exactly one of each of the %d action keys", len(ActionByRune))
+	}
+
+	return layout, nil
+}
+
+// checkConnected makes sure that every key of the pad can be reached from its first key in reading order, moving up,
+// down, left and right between keys, and locates the first key that cannot.
+func checkConnected(rows []padRow, layout [][]int, gapKey int, padName string) error {
+	isKey := func(coord Coord) bool {
+		return coord.Row >= 0 && coord.Row < len(layout) && coord.Col >= 0 && coord.Col < len(layout[coord.Row]) &&
+			layout[coord.Row][coord.Col] != gapKey
+	}
+
+	reached := make(map[Coord]bool)
+	var queue []Coord
+	for iRow, row := range layout {
+		for iCol := range row {
+			if len(queue) < 1 && isKey(Coord{Row: iRow, Col: iCol}) {
+				queue = append(queue, Coord{Row: iRow, Col: iCol})
+				reached[queue[0]] = true
+			}
+		}
+	}
+	for len(queue) > 0 {
+		coord := queue[0]
+		queue = queue[1:]
+		for _, action := range []int{MoveUp, MoveDown, MoveLeft, MoveRight} {
+			next := coord.Add(Actions[action])
+			if isKey(next) && !reached[next] {
+				reached[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	for iRow, row := range rows {
+		iCol := 0
+		for iByte, r := range row.Text {
+			coord := Coord{Row: iRow, Col: iCol}
+			if isKey(coord) && !reached[coord] {
+				return parsing.Errorf(row.Line, iByte+1, string(r), "%s key cannot be reached from the other keys", padName)
+			}
+			iCol++
+		}
+	}
+
+	return nil
+}
+
+type NumPadCode []int
+
+func ReadInput(scanner *bufio.Scanner, numPadKeyByRune map[rune]int) ([]NumPadCode, error) {
+	var numPadCodes []NumPadCode
+	iLine := 0
+	for scanner.Scan() {
+		line := scanner.Text()
+		iLine++
+		trimmedLine := strings.TrimSpace(line)
+		if len(trimmedLine) < 1 {
+			continue
+		}
 
-		if lo.Contains(numPadCode, InvalidNumPadKey) {
-			return nil, fmt.Errorf("invalid num pad code: %v (line %d)", numPadCode, iLine)
+		lineCol := strings.Index(line, trimmedLine) + 1
+		var numPadCode NumPadCode
+		for iByte, r := range trimmedLine {
+			key, ok := numPadKeyByRune[r]
+			if !ok {
+				return nil, parsing.Errorf(iLine, lineCol+iByte, string(r), "invalid num pad key in code %q", trimmedLine)
+			}
+			numPadCode = append(numPadCode, key)
 		}
 
 		numPadCodes = append(numPadCodes, numPadCode)
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
 
 	return numPadCodes, nil
 }
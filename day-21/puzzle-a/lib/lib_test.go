@@ -0,0 +1,152 @@
+package lib
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"testing"
+
+	"common/parsing"
+	"common/parsing/parsingtest"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// printLayout draws the pad rows back out, with gaps as dots.
+func printLayout(builder *strings.Builder, layout [][]int, runeByKey map[int]rune) {
+	for _, row := range layout {
+		for _, key := range row {
+			builder.WriteRune(lo.ValueOr(runeByKey, key, layoutGapRune))
+		}
+		builder.WriteByte('\n')
+	}
+	builder.WriteByte('\n')
+}
+
+// printKeypadChain writes the chain back out in the layout file format.
+func printKeypadChain(chain *KeypadChain) string {
+	runeByNumPadKey := lo.Invert(chain.NumPadKeyByRune)
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "depth %d\nstart %c\nnumpad\n", chain.NumIntermediateKeypads, runeByNumPadKey[chain.NumPadStartKey])
+	printLayout(&builder, chain.NumPadLayout, runeByNumPadKey)
+	builder.WriteString("actionpad\n")
+	printLayout(&builder, chain.ActionPadLayout, lo.Invert(ActionByRune))
+	return builder.String()
+}
+
+func FuzzReadKeypadChain(f *testing.F) {
+	f.Add("")
+	f.Add("# Default chain\ndepth 2\nstart A\n\nnumpad\n789\n456\n123\n.0A\n\nactionpad\n.^A\n<v>\n")
+	f.Add("depth 25\nnumpad\nAB\nC.\n")
+	f.Add("start Z\n")
+	f.Add("depth -1\n")
+	f.Add("numpad\n. .\n")
+	f.Add("numpad\n12\n.1\n")
+	f.Add("actionpad\n^^A\n<v>\n")
+	f.Add("actionpad\n.^A\n<x>\n")
+	f.Add("actionpad\n.^A\n")
+	f.Add("actionpad\n^..A\n<v>..\n")
+	f.Add("numpad\n# Keys\n1.2\n")
+	f.Add("keypad\n")
+	f.Fuzz(func(t *testing.T, input string) {
+		chain, err := ReadKeypadChain(bufio.NewScanner(strings.NewReader(input)))
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+		assert.Contains(t, lo.Values(chain.NumPadKeyByRune), chain.NumPadStartKey)
+		assert.GreaterOrEqual(t, chain.NumIntermediateKeypads, 0)
+
+		// Keys are numbered in reading order, so compare the printed layouts. A key of \r ending a pad row would be read
+		// back as part of a \r\n line ending.
+		if !strings.Contains(input, "\r") {
+			reread, err := ReadKeypadChain(bufio.NewScanner(strings.NewReader(printKeypadChain(chain))))
+			require.NoError(t, err)
+			assert.Equal(t, printKeypadChain(chain), printKeypadChain(reread))
+		}
+	})
+}
+
+func TestReadKeypadChainDuplicateKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		line  int
+		col   int
+		text  string
+	}{
+		{name: "num pad", input: "depth 1\nnumpad\n12\n.1\n", line: 4, col: 2, text: "1"},
+		{name: "num pad on one row", input: "numpad\nAxA\n", line: 2, col: 3, text: "A"},
+		{name: "action pad", input: "actionpad\n.^A\n<^>\n", line: 3, col: 2, text: "^"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ReadKeypadChain(bufio.NewScanner(strings.NewReader(test.input)))
+			var parseErr *parsing.Error
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, test.line, parseErr.Line)
+			assert.Equal(t, test.col, parseErr.Col)
+			assert.Equal(t, test.text, parseErr.Text)
+			assert.ErrorContains(t, err, "duplicate")
+		})
+	}
+}
+
+func TestReadKeypadChainUnreachableKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		line  int
+		col   int
+		text  string
+	}{
+		{name: "num pad", input: "numpad\n12\n..\n.3\n", line: 4, col: 2, text: "3"},
+		{name: "num pad after a comment", input: "numpad\n# Keys\n1.2\n", line: 3, col: 3, text: "2"},
+		{name: "action pad", input: "actionpad\n^..A\n<v>..\n", line: 2, col: 4, text: "A"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ReadKeypadChain(bufio.NewScanner(strings.NewReader(test.input)))
+			var parseErr *parsing.Error
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, test.line, parseErr.Line)
+			assert.Equal(t, test.col, parseErr.Col)
+			assert.Equal(t, test.text, parseErr.Text)
+			assert.ErrorContains(t, err, "cannot be reached")
+		})
+	}
+}
+
+func TestReadKeypadChainComments(t *testing.T) {
+	chain, err := ReadKeypadChain(bufio.NewScanner(strings.NewReader("start 1\nnumpad\n# Top row\n12\n  # Bottom row\n3.\n")))
+	require.NoError(t, err)
+	assert.Equal(t, [][]int{{1, 2}, {3, InvalidNumPadKey}}, chain.NumPadLayout)
+}
+
+func FuzzReadInput(f *testing.F) {
+	f.Add("029A\n980A\n179A\n456A\n379A\n")
+	f.Add("  029A  \n\n")
+	f.Add("02B9A\n")
+	f.Add("é\n")
+	f.Fuzz(func(t *testing.T, input string) {
+		codes, err := ReadInput(bufio.NewScanner(strings.NewReader(input)), NumPadKeyByRune)
+		if err != nil {
+			parsingtest.CheckError(t, input, err)
+			return
+		}
+
+		runeByKey := lo.Invert(NumPadKeyByRune)
+		var builder strings.Builder
+		for _, code := range codes {
+			for _, key := range code {
+				builder.WriteRune(runeByKey[key])
+			}
+			builder.WriteByte('\n')
+		}
+		reread, err := ReadInput(bufio.NewScanner(strings.NewReader(builder.String())), NumPadKeyByRune)
+		require.NoError(t, err)
+		assert.Equal(t, codes, reread)
+	})
+}
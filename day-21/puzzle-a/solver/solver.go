@@ -0,0 +1,526 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"common/command"
+	"common/parsing"
+	"common/report"
+	"daytwentyone/a/lib"
+
+	"github.com/hashicorp/go-set/v3"
+	"github.com/samber/lo"
+	pq "gopkg.in/dnaeon/go-priorityqueue.v1"
+)
+
+type Args struct {
+	command.Flags
+
+	LayoutFile string   `arg:"-l,--layout" help:"keypad chain layout file"`
+	Explain    []string `arg:"-e,--explain,separate" help:"explain the optimal presses for a code through every layer"`
+}
+
+// NeedsInput tells the command to look for the cached input only when there are no codes to explain.
+func (a Args) NeedsInput() bool {
+	return len(a.Explain) < 1
+}
+
+type NumPadLayoutMap map[lib.Coord]int
+type NumPadRevLayoutMap map[int]lib.Coord
+type ActionPadLayoutMap map[lib.Coord]int
+type ActionPadRevLayoutMap map[int]lib.Coord
+
+type NumPadMaps struct {
+	Layout           NumPadLayoutMap
+	RevLayout        NumPadRevLayoutMap
+	RunesByNumPadKey map[int]rune
+	StartKey         int
+}
+
+type ActionPadMaps struct {
+	Layout        ActionPadLayoutMap
+	RevLayout     ActionPadRevLayoutMap
+	RunesByAction map[int]rune
+}
+
+type AllMaps struct {
+	NumPad    NumPadMaps
+	ActionPad ActionPadMaps
+}
+
+type State struct {
+	CurrentStr string
+	NextCoord  lib.Coord
+	NextStr    string
+}
+
+var errOutOfBounds = errors.New("out-of-bounds")
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	chain, err := readLayoutFile(args)
+	if err != nil {
+		return parsing.InFile(args.LayoutFile, err)
+	}
+
+	if len(args.Explain) > 0 {
+		allMaps := AllMaps{NumPad: makeNumPadMaps(chain), ActionPad: makeActionPadMaps(chain)}
+		for _, target := range args.Explain {
+			err := explain(ctx, target, allMaps, chain.NumIntermediateKeypads)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	numPadCodes, err := readInput(input, chain, out)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	slog.Debug("read codes", "count", len(numPadCodes), "codes", numPadCodes)
+	out.Parsed("codes", len(numPadCodes))
+
+	allMaps := AllMaps{NumPad: makeNumPadMaps(chain), ActionPad: makeActionPadMaps(chain)}
+	slog.Debug("num pad layout", "layout", allMaps.NumPad.Layout, "revLayout", allMaps.NumPad.RevLayout)
+	slog.Debug("action pad layout", "layout", allMaps.ActionPad.Layout, "revLayout", allMaps.ActionPad.RevLayout)
+
+	total := int64(0)
+	for _, numPadCode := range numPadCodes {
+		numPadString := string(lo.Map(numPadCode, func(key, _ int) rune {
+			return allMaps.NumPad.RunesByNumPadKey[key]
+		}))
+		value, err := solve(ctx, numPadString, allMaps, chain.NumIntermediateKeypads)
+		if err != nil {
+			return err
+		}
+		slog.Debug("solved code", "code", numPadString, "value", value)
+		total += value
+	}
+
+	slog.Info("solved", "total", total)
+	out.Answer("total", total)
+
+	return nil
+}
+
+func solve(ctx context.Context, target string, allMaps AllMaps, numIntermediateKeypads int) (int64, error) {
+	solutions, err := findSolutions(ctx, target, allMaps, numIntermediateKeypads)
+	if err != nil {
+		return -1, err
+	}
+
+	minLength := len(solutions[0])
+	slog.Debug("shortest solution", "length", minLength)
+
+	value, err := numericPart(target)
+	if err != nil {
+		return -1, err
+	}
+
+	return value * int64(minLength), nil
+}
+
+// findSolutions returns every shortest sequence of presses on the first action pad that types the target on the num
+// pad, after verifying each of them by replaying it through the chain.
+func findSolutions(ctx context.Context, target string, allMaps AllMaps, numIntermediateKeypads int) ([]string, error) {
+	solutionsKeypad, err := doDijkstra(ctx, target, allMaps.NumPad.RunesByNumPadKey, allMaps.NumPad.Layout, allMaps.NumPad.RevLayout[allMaps.NumPad.StartKey])
+	if err != nil {
+		return nil, err
+	}
+	slog.Debug("keypad solutions", "solutions", len(solutionsKeypad))
+	if len(solutionsKeypad) < 1 {
+		return nil, fmt.Errorf("no solution found for code `%s`", target)
+	}
+
+	filteredSolutionsPrev := solutionsKeypad
+	for iKeyPad := numIntermediateKeypads; iKeyPad > 0; iKeyPad-- {
+		var solutionsNext []string
+		for _, solutionPrev := range filteredSolutionsPrev {
+			subSolutionsNext, err := doDijkstra(ctx, solutionPrev, allMaps.ActionPad.RunesByAction, allMaps.ActionPad.Layout, allMaps.ActionPad.RevLayout[lib.Press])
+			if err != nil {
+				return nil, err
+			}
+			solutionsNext = append(solutionsNext, subSolutionsNext...)
+		}
+		slog.Debug("next solutions", "round", iKeyPad, "solutions", len(solutionsNext))
+		if len(solutionsNext) < 1 {
+			return nil, fmt.Errorf("no solution found for code `%s` on action pad", target)
+		}
+		filteredSolutionsPrev = shortestOnly(solutionsNext)
+		slog.Debug("next solutions after filtering", "round", iKeyPad, "solutions", len(filteredSolutionsPrev))
+	}
+
+	filteredSolutions := shortestOnly(filteredSolutionsPrev)
+
+	for _, solution := range filteredSolutions {
+		numPadString, err := execUberSequence(solution, allMaps, numIntermediateKeypads)
+		if err != nil {
+			return nil, err
+		}
+		if numPadString != target {
+			return nil, fmt.Errorf("internal error: num pad string does not match target: `%s` != `%s`", numPadString, target)
+		}
+	}
+	slog.Debug("verified solutions", "solutions", len(filteredSolutions))
+
+	return filteredSolutions, nil
+}
+
+func shortestOnly(solutions []string) []string {
+	minLength := lo.Min(lo.Map(solutions, func(s string, _ int) int {
+		return len(s)
+	}))
+
+	return lo.Filter(solutions, func(s string, _ int) bool {
+		return len(s) == minLength
+	})
+}
+
+// explain replays one shortest solution for the target through every layer of the chain and logs the presses made at
+// each layer, with every press placed in the column of the human press that triggers it.
+func explain(ctx context.Context, target string, allMaps AllMaps, numIntermediateKeypads int) error {
+	solutions, err := findSolutions(ctx, target, allMaps, numIntermediateKeypads)
+	if err != nil {
+		return err
+	}
+
+	solution := slices.Min(solutions)
+	layers, err := execLayers(solution, allMaps, numIntermediateKeypads)
+	if err != nil {
+		return err
+	}
+
+	labels := make([]string, len(layers))
+	labels[0] = "human"
+	for iLayer := 1; iLayer < len(layers)-1; iLayer++ {
+		labels[iLayer] = fmt.Sprintf("robot %d", iLayer)
+	}
+	labels[len(layers)-1] = "num pad"
+	labelWidth := lo.Max(lo.Map(labels, func(label string, _ int) int {
+		return len(label)
+	}))
+
+	slog.Info("explaining code", "code", target, "presses", len(solution))
+	for iLayer, line := range alignLayers(layers, allMaps.ActionPad.RunesByAction[lib.Press]) {
+		fmt.Fprintf(os.Stderr, "%-*s | %s\n", labelWidth, labels[iLayer], line)
+	}
+
+	return nil
+}
+
+// alignLayers places every press of a layer in the column of the human press that triggers it, knowing that the
+// presses of a layer are triggered one for one by the press runes of the layer above.
+func alignLayers(layers []string, pressRune rune) []string {
+	width := utf8.RuneCountInString(layers[0])
+	cols := lo.Range(width)
+	lines := make([]string, len(layers))
+	for iLayer, layer := range layers {
+		layerRunes := []rune(layer)
+		line := []rune(strings.Repeat(" ", width))
+		for i, r := range layerRunes {
+			line[cols[i]] = r
+		}
+		lines[iLayer] = strings.TrimRight(string(line), " ")
+
+		cols = lo.Filter(cols, func(_ int, i int) bool {
+			return layerRunes[i] == pressRune
+		})
+	}
+
+	return lines
+}
+
+// numericPart returns the number formed by the decimal digits of the code, ignoring any other keys; codes without
+// digits are worth zero.
+func numericPart(target string) (int64, error) {
+	digits := strings.Map(func(r rune) rune {
+		if r < '0' || r > '9' {
+			return -1
+		}
+		return r
+	}, target)
+	if len(digits) < 1 {
+		return 0, nil
+	}
+
+	value, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return -1, fmt.Errorf("failed to parse numeric part of code `%s`: %w", target, err)
+	}
+
+	return value, nil
+}
+
+func execUberSequence(s string, allMaps AllMaps, numIntermediateKeypads int) (string, error) {
+	layers, err := execLayers(s, allMaps, numIntermediateKeypads)
+	if err != nil {
+		return "", err
+	}
+
+	return layers[len(layers)-1], nil
+}
+
+// execLayers replays the human presses through the chain and returns the presses made at every layer, from the human
+// presses on the first action pad down to the keys pressed on the num pad.
+func execLayers(s string, allMaps AllMaps, numIntermediateKeypads int) ([]string, error) {
+	actionSeq := lo.Map([]rune(s), func(r rune, _ int) int {
+		return lo.ValueOr(lib.ActionByRune, r, lib.InvalidAction)
+	})
+	actionSeqToString := func(actions []int) string {
+		return string(lo.Map(actions, func(action, _ int) rune {
+			return allMaps.ActionPad.RunesByAction[action]
+		}))
+	}
+
+	layers := []string{actionSeqToString(actionSeq)}
+	for range numIntermediateKeypads {
+		var err error
+		actionSeq, err = execSeqOnActionPad(actionSeq, allMaps.ActionPad)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, actionSeqToString(actionSeq))
+	}
+
+	keyPresses, err := execSeqOnNumPad(actionSeq, allMaps.NumPad)
+	if err != nil {
+		return nil, err
+	}
+	layers = append(layers, string(lo.Map(keyPresses, func(key, _ int) rune {
+		return allMaps.NumPad.RunesByNumPadKey[key]
+	})))
+
+	return layers, nil
+}
+
+func doDijkstra(
+	ctx context.Context, targetString string, runesByKey map[int]rune, nextLayout map[lib.Coord]int,
+	initialCoord lib.Coord,
+) ([]string, error) {
+	initState := State{
+		NextCoord: initialCoord,
+	}
+	bestCostByState := make(map[State]int)
+	unvisitedQueue := pq.New[State, float64](pq.MinHeap)
+	unvisitedQueue.Put(initState, 0)
+	removed := set.New[State](0)
+	results := make([]string, 0)
+	for !unvisitedQueue.IsEmpty() {
+		err := ctx.Err()
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Toy code
+		}
+
+		item := unvisitedQueue.Get()
+		state, _ := item.Value, item.Priority
+
+		removed.Insert(state)
+		if state.NextStr == targetString {
+			results = append(results, state.CurrentStr)
+			continue
+		}
+
+		for nextRune := range lib.ActionByRune {
+			newState := state
+			var action int
+			var err error
+			action, err = processAction(
+				nextRune,
+				&newState.CurrentStr,
+				&newState.NextCoord,
+				nextLayout,
+			)
+			if errors.Is(err, errOutOfBounds) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+
+			if action != lib.InvalidNumPadKey {
+				nextRune = runesByKey[action]
+				newState.NextStr += string(nextRune)
+			}
+
+			if !strings.HasPrefix(targetString, newState.NextStr) {
+				continue
+			}
+
+			newCost := len(newState.CurrentStr)
+			stateKey := State{
+				CurrentStr: "",
+				NextCoord:  newState.NextCoord,
+				NextStr:    newState.NextStr,
+			}
+			if oldBest, ok := bestCostByState[stateKey]; ok {
+				switch {
+				case newCost < oldBest:
+					unvisitedQueue.Update(newState, float64(newCost))
+				case newCost > oldBest:
+					newCost = oldBest
+				default:
+					unvisitedQueue.Put(newState, float64(newCost))
+				}
+			} else {
+				unvisitedQueue.Put(newState, float64(newCost))
+			}
+			bestCostByState[stateKey] = newCost
+		}
+	}
+
+	return results, nil
+}
+
+func processAction(r rune, currentStr *string, nextCoords *lib.Coord, nextLayout map[lib.Coord]int) (int, error) {
+	*currentStr += string(r)
+	action := lib.ActionByRune[r]
+	switch action {
+	case lib.Press:
+		newAction := nextLayout[*nextCoords]
+		return newAction, nil
+	default:
+		*nextCoords = nextCoords.Add(lib.Actions[action])
+		if !lo.HasKey(nextLayout, *nextCoords) {
+			return lib.InvalidAction, errOutOfBounds
+		}
+		return lib.InvalidAction, nil
+	}
+}
+
+func execSeqOnNumPad(actions []int, numPadMaps NumPadMaps) ([]int, error) {
+	coord := numPadMaps.RevLayout[numPadMaps.StartKey]
+	keyPresses := make([]int, 0)
+	for _, action := range actions {
+		switch action {
+		case lib.InvalidAction:
+			return nil, errors.New("encountered invalid action while executing numpad sequence")
+		case lib.Press:
+			keyPresses = append(keyPresses, numPadMaps.Layout[coord])
+		default:
+			dir := lib.Actions[action]
+			nextCoord := coord.Add(dir)
+			if !lo.HasKey(numPadMaps.Layout, nextCoord) {
+				return nil, fmt.Errorf("out-of-bounds while executing numpad sequence: %v", nextCoord)
+			}
+			coord = nextCoord
+		}
+	}
+
+	return keyPresses, nil
+}
+
+func execSeqOnActionPad(actions []int, actionPadMaps ActionPadMaps) ([]int, error) {
+	coord := actionPadMaps.RevLayout[lib.Press]
+	keyPresses := make([]int, 0)
+	for _, action := range actions {
+		switch action {
+		case lib.InvalidAction:
+			return nil, errors.New("encountered invalid action while executing actionpad sequence")
+		case lib.Press:
+			keyPresses = append(keyPresses, actionPadMaps.Layout[coord])
+		default:
+			dir := lib.Actions[action]
+			nextCoord := coord.Add(dir)
+			if !lo.HasKey(actionPadMaps.Layout, nextCoord) {
+				return nil, fmt.Errorf("out-of-bounds while executing actionpad sequence: %v", nextCoord)
+			}
+			coord = nextCoord
+		}
+	}
+
+	return keyPresses, nil
+}
+
+func makeActionPadMaps(chain *lib.KeypadChain) ActionPadMaps {
+	actionPadMaps := ActionPadMaps{}
+	actionPadMaps.Layout = make(ActionPadLayoutMap)
+	for iRow, row := range chain.ActionPadLayout {
+		for iCol, key := range row {
+			if key == lib.InvalidAction {
+				continue
+			}
+
+			actionPadMaps.Layout[lib.Coord{Row: iRow, Col: iCol}] = key
+		}
+	}
+
+	actionPadMaps.RevLayout = make(ActionPadRevLayoutMap)
+	for iRow, row := range chain.ActionPadLayout {
+		for iCol, key := range row {
+			if key == lib.InvalidAction {
+				continue
+			}
+
+			actionPadMaps.RevLayout[key] = lib.Coord{Row: iRow, Col: iCol}
+		}
+	}
+
+	actionPadMaps.RunesByAction = lo.Invert(lib.ActionByRune)
+
+	return actionPadMaps
+}
+
+func makeNumPadMaps(chain *lib.KeypadChain) NumPadMaps {
+	numPadMaps := NumPadMaps{}
+	numPadMaps.Layout = make(NumPadLayoutMap)
+	for iRow, row := range chain.NumPadLayout {
+		for iCol, key := range row {
+			if key == lib.InvalidNumPadKey {
+				continue
+			}
+
+			numPadMaps.Layout[lib.Coord{Row: iRow, Col: iCol}] = key
+		}
+	}
+
+	numPadMaps.RevLayout = make(NumPadRevLayoutMap)
+	for iRow, row := range chain.NumPadLayout {
+		for iCol, key := range row {
+			if key == lib.InvalidNumPadKey {
+				continue
+			}
+
+			numPadMaps.RevLayout[key] = lib.Coord{Row: iRow, Col: iCol}
+		}
+	}
+
+	numPadMaps.RunesByNumPadKey = lo.Invert(chain.NumPadKeyByRune)
+	numPadMaps.StartKey = chain.NumPadStartKey
+
+	return numPadMaps
+}
+
+func readLayoutFile(args Args) (chain *lib.KeypadChain, err error) {
+	if args.LayoutFile == "" {
+		return lib.DefaultKeypadChain(), nil
+	}
+
+	file, err := os.Open(args.LayoutFile)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	return lib.ReadKeypadChain(bufio.NewScanner(file)) //nolint:wrapcheck // Toy code
+}
+
+func readInput(input io.Reader, chain *lib.KeypadChain, out *report.Report) ([]lib.NumPadCode, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	numPadCodes, err := lib.ReadInput(scanner, chain.NumPadKeyByRune)
+
+	return numPadCodes, err //nolint:wrapcheck // Toy code
+}
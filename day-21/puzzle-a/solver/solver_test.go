@@ -0,0 +1,99 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"common/parsing"
+	"common/report"
+	"daytwentyone/a/lib"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func defaultMaps() AllMaps {
+	chain := lib.DefaultKeypadChain()
+	return AllMaps{NumPad: makeNumPadMaps(chain), ActionPad: makeActionPadMaps(chain)}
+}
+
+// The presses of the puzzle statement for code 029A, from the human down to the num pad.
+var exampleLayers = []string{
+	"<vA<AA>>^AvAA<^A>A<v<A>>^AvA^A<vA>^A<v<A>^A>AAvA^A<v<A>A>^AAAvA<^A>A",
+	"v<<A>>^A<A>AvA<^AA>A<vAAA>^A",
+	"<A^A>^^AvvvA",
+	"029A",
+}
+
+func TestExecLayers(t *testing.T) {
+	layers, err := execLayers(exampleLayers[0], defaultMaps(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, exampleLayers, layers)
+
+	_, err = execLayers("<<<A", defaultMaps(), 2)
+	require.ErrorContains(t, err, "out-of-bounds")
+}
+
+func TestAlignLayers(t *testing.T) {
+	assert.Equal(t, []string{"ab*c*", "  x *", "    9"}, alignLayers([]string{"ab*c*", "x*", "9"}, '*'))
+	assert.Equal(t, []string{"é*", " ü"}, alignLayers([]string{"é*", "ü"}, '*'))
+
+	lines := alignLayers(exampleLayers, 'A')
+	require.Len(t, lines, len(exampleLayers))
+	for iLayer, line := range lines {
+		assert.Equal(t, exampleLayers[iLayer], strings.ReplaceAll(line, " ", ""))
+		if iLayer == 0 {
+			continue
+		}
+		// Every press sits below the press of the layer above that triggered it.
+		above := lines[iLayer-1]
+		for col, r := range line {
+			if r != ' ' {
+				assert.Equal(t, 'A', rune(above[col]), "layer %d, column %d", iLayer, col)
+			}
+		}
+	}
+	assert.Len(t, lines[3], len(exampleLayers[0]))
+}
+
+// layoutTests type code 12A on a single row num pad, where the robot presses <<A>A>A, worth 7 presses directly.
+var layoutTests = []struct {
+	name   string
+	layout string
+	total  int64
+}{
+	{name: "no robot", layout: "depth 0\nnumpad\n12A\n", total: 7 * 12},
+	// v<<A A >>^A vA ^A vA ^A on the default action pad.
+	{name: "default action pad", layout: "depth 1\nnumpad\n12A\n", total: 17 * 12},
+	// <<<<A A >>>>A <A >A <A >A on an action pad of a single row.
+	{name: "single row action pad", layout: "depth 1\nnumpad\n12A\n\nactionpad\n<^v>A\n", total: 19 * 12},
+}
+
+func writeLayout(t *testing.T, layout string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "layout.txt")
+	require.NoError(t, os.WriteFile(path, []byte(layout), 0o600))
+	return path
+}
+
+func TestSolveLayout(t *testing.T) {
+	for _, test := range layoutTests {
+		t.Run(test.name, func(t *testing.T) {
+			out := report.New(report.JSON)
+			args := Args{LayoutFile: writeLayout(t, test.layout)}
+			require.NoError(t, Solve(context.Background(), args, strings.NewReader("12A\n"), out))
+			assert.Equal(t, fmt.Sprint(test.total), fmt.Sprint(out.Answers()["total"]))
+		})
+	}
+
+	path := writeLayout(t, "actionpad\n^..A\n<v>..\n")
+	err := Solve(context.Background(), Args{LayoutFile: path}, strings.NewReader("12A\n"), report.New(report.JSON))
+	var parseErr *parsing.Error
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, path, parseErr.File)
+	assert.ErrorContains(t, err, "action pad key cannot be reached")
+}
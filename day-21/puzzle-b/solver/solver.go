@@ -0,0 +1,707 @@
+// Package solver holds the puzzle solution, so that both the command and the HTTP server can run it.
+package solver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"common/command"
+	"common/logging"
+	"common/parsing"
+	"common/report"
+	"daytwentyone/b/lib"
+
+	"github.com/hashicorp/go-set/v3"
+	"github.com/samber/lo"
+	pq "gopkg.in/dnaeon/go-priorityqueue.v1"
+)
+
+type Args struct {
+	command.Flags
+
+	LayoutFile             string `arg:"-l,--layout" help:"keypad chain layout file"`
+	NumIntermediateKeypads *int   `arg:"-n" help:"number of intermediate keypads (overrides the layout file)"`
+	Strings                bool   `arg:"-s,--strings" help:"materialize candidate press strings (only feasible up to 2 intermediate keypads)"`
+}
+
+type NumPadLayoutMap map[lib.Coord]int
+type NumPadRevLayoutMap map[int]lib.Coord
+type ActionPadLayoutMap map[lib.Coord]int
+type ActionPadRevLayoutMap map[int]lib.Coord
+
+type NumPadMaps struct {
+	Layout           NumPadLayoutMap
+	RevLayout        NumPadRevLayoutMap
+	RunesByNumPadKey map[int]rune
+	StartKey         int
+}
+
+type ActionPadMaps struct {
+	Layout        ActionPadLayoutMap
+	RevLayout     ActionPadRevLayoutMap
+	RunesByAction map[int]rune
+}
+
+type AllMaps struct {
+	NumPad    NumPadMaps
+	ActionPad ActionPadMaps
+}
+
+type State struct {
+	CurrentStr string
+	NextCoord  lib.Coord
+	NextStr    string
+}
+
+// LengthKey identifies a move of the arm on the action pad at a given depth, from one action key to another.
+type LengthKey struct {
+	From  int
+	To    int
+	Depth int
+}
+
+// maxStringsDepth is the deepest chain the strings mode is run on: it takes close to a minute at depth 2, and runs out
+// of memory at depth 3.
+const maxStringsDepth = 2
+
+var errOutOfBounds = errors.New("out-of-bounds")
+
+var moveActions = []int{lib.MoveUp, lib.MoveDown, lib.MoveLeft, lib.MoveRight} //nolint:gochecknoglobals // Meant as a constant
+
+// Solve reads the puzzle input and records the answers in out.
+func Solve(ctx context.Context, args Args, input io.Reader, out *report.Report) error {
+	chain, err := readLayoutFile(args)
+	if err != nil {
+		return parsing.InFile(args.LayoutFile, err)
+	}
+	if args.NumIntermediateKeypads != nil {
+		chain.NumIntermediateKeypads = *args.NumIntermediateKeypads
+	}
+
+	numPadCodes, err := readInput(input, chain, out)
+	if err != nil {
+		return err //nolint:wrapcheck // Toy code
+	}
+
+	slog.Debug("read codes", "count", len(numPadCodes), "codes", numPadCodes)
+	out.Parsed("codes", len(numPadCodes))
+
+	allMaps := AllMaps{NumPad: makeNumPadMaps(chain), ActionPad: makeActionPadMaps(chain)}
+	slog.Debug("num pad layout", "layout", allMaps.NumPad.Layout, "revLayout", allMaps.NumPad.RevLayout)
+	slog.Debug("action pad layout", "layout", allMaps.ActionPad.Layout, "revLayout", allMaps.ActionPad.RevLayout)
+
+	numPadStrings := lo.Map(numPadCodes, func(numPadCode lib.NumPadCode, _ int) string {
+		return string(lo.Map(numPadCode, func(key, _ int) rune {
+			return allMaps.NumPad.RunesByNumPadKey[key]
+		}))
+	})
+
+	if args.Strings {
+		if chain.NumIntermediateKeypads > maxStringsDepth {
+			return fmt.Errorf("--strings materializes every candidate and is limited to %d intermediate keypads; got %d",
+				maxStringsDepth, chain.NumIntermediateKeypads)
+		}
+		total, err := solveWithStrings(ctx, numPadStrings, allMaps, chain)
+		if err != nil {
+			return err
+		}
+		slog.Info("solved", "total", total)
+		out.Answer("total", total)
+		return nil
+	}
+
+	total := new(big.Int)
+	lengths := newLengthSolver(allMaps)
+	for _, numPadString := range numPadStrings {
+		value, err := lengths.solveAndMultiply(ctx, numPadString, chain.NumIntermediateKeypads)
+		if err != nil {
+			return err
+		}
+		slog.Debug("solved code", "code", numPadString, "value", value)
+		total.Add(total, value)
+	}
+
+	slog.Info("solved", "total", total)
+	out.Answer("total", total)
+
+	return nil
+}
+
+func solveWithStrings(
+	ctx context.Context, numPadStrings []string, allMaps AllMaps, chain *lib.KeypadChain,
+) (int64, error) {
+	total := int64(0)
+	solutionsCache := make(map[string][]string)
+	// Solving every key alone first fills the cache with the presses for short sequences, which the searches for whole
+	// codes then stitch together.
+	for r := range chain.NumPadKeyByRune {
+		_, err := solveKeyPad(ctx, string([]rune{r}), solutionsCache, allMaps, chain.NumIntermediateKeypads)
+		if err != nil {
+			return 0, err
+		}
+	}
+	for _, numPadString := range numPadStrings {
+		value, err := solveAndMultiply(ctx, numPadString, solutionsCache, allMaps, chain.NumIntermediateKeypads)
+		if err != nil {
+			return 0, err
+		}
+		slog.Debug("solved code", "code", numPadString, "value", value)
+		total += value
+	}
+
+	return total, nil
+}
+
+// lengthSolver counts the human presses that type codes without materializing any sequence of presses. It memoises the
+// shortest moves between two keys of either pad, and the presses needed for every move at every depth.
+type lengthSolver struct {
+	allMaps        AllMaps
+	numPadMoves    *moveFinder
+	actionPadMoves *moveFinder
+	lengths        map[LengthKey]*big.Int
+}
+
+func newLengthSolver(allMaps AllMaps) *lengthSolver {
+	return &lengthSolver{
+		allMaps:        allMaps,
+		numPadMoves:    newMoveFinder(allMaps.NumPad.Layout),
+		actionPadMoves: newMoveFinder(allMaps.ActionPad.Layout),
+		lengths:        make(map[LengthKey]*big.Int),
+	}
+}
+
+func (l *lengthSolver) solveAndMultiply(
+	ctx context.Context, target string, numIntermediateKeypads int,
+) (*big.Int, error) {
+	solutionLength, err := l.solveKeyPad(ctx, target, numIntermediateKeypads)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := numericPart(target)
+	if err != nil {
+		return nil, err
+	}
+
+	return solutionLength.Mul(solutionLength, big.NewInt(value)), nil
+}
+
+// solveKeyPad returns the length of the shortest sequence of human presses that types the target on the num pad.
+func (l *lengthSolver) solveKeyPad(ctx context.Context, target string, numIntermediateKeypads int) (*big.Int, error) {
+	numPadKeyByRune := lo.Invert(l.allMaps.NumPad.RunesByNumPadKey)
+	total := new(big.Int)
+	fromKey := l.allMaps.NumPad.StartKey
+	for _, r := range target {
+		toKey, ok := numPadKeyByRune[r]
+		if !ok {
+			return nil, fmt.Errorf("key `%c` not found on num pad", r)
+		}
+
+		var best *big.Int
+		for _, moves := range l.numPadMoves.shortestMoves(l.allMaps.NumPad.RevLayout[fromKey], l.allMaps.NumPad.RevLayout[toKey]) {
+			length, err := l.movesLength(ctx, moves, numIntermediateKeypads)
+			if err != nil {
+				return nil, err
+			}
+			if best == nil || length.Cmp(best) < 0 {
+				best = length
+			}
+		}
+		if best == nil {
+			return nil, fmt.Errorf("key `%c` cannot be reached on num pad", r)
+		}
+
+		total.Add(total, best)
+		fromKey = toKey
+	}
+
+	return total, nil
+}
+
+// movesLength returns the number of human presses needed to make the robot operating the action pad at the given depth
+// perform the moves, where depth 0 is the action pad operated by the human.
+func (l *lengthSolver) movesLength(ctx context.Context, moves []int, depth int) (*big.Int, error) {
+	if depth == 0 {
+		return big.NewInt(int64(len(moves))), nil
+	}
+
+	total := new(big.Int)
+	fromAction := lib.Press
+	for _, toAction := range moves {
+		length, err := l.pressLength(ctx, fromAction, toAction, depth)
+		if err != nil {
+			return nil, err
+		}
+		total.Add(total, length)
+		fromAction = toAction
+	}
+
+	return total, nil
+}
+
+func (l *lengthSolver) pressLength(ctx context.Context, fromAction, toAction int, depth int) (*big.Int, error) {
+	key := LengthKey{From: fromAction, To: toAction, Depth: depth}
+	if length, ok := l.lengths[key]; ok {
+		return length, nil
+	}
+	err := ctx.Err()
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+
+	var best *big.Int
+	revLayout := l.allMaps.ActionPad.RevLayout
+	for _, moves := range l.actionPadMoves.shortestMoves(revLayout[fromAction], revLayout[toAction]) {
+		length, err := l.movesLength(ctx, moves, depth-1)
+		if err != nil {
+			return nil, err
+		}
+		if best == nil || length.Cmp(best) < 0 {
+			best = length
+		}
+	}
+	if best == nil {
+		runesByAction := l.allMaps.ActionPad.RunesByAction
+		return nil, fmt.Errorf("key `%c` cannot be reached from key `%c` on action pad", runesByAction[toAction], runesByAction[fromAction])
+	}
+
+	l.lengths[key] = best
+
+	return best, nil
+}
+
+// moveFinder finds the shortest moves between two keys of a layout, memoising them along with the distances to every
+// key.
+type moveFinder struct {
+	layout    map[lib.Coord]int
+	distances map[lib.Coord]map[lib.Coord]int
+	moves     map[[2]lib.Coord][][]int
+}
+
+func newMoveFinder(layout map[lib.Coord]int) *moveFinder {
+	return &moveFinder{
+		layout:    layout,
+		distances: make(map[lib.Coord]map[lib.Coord]int),
+		moves:     make(map[[2]lib.Coord][][]int),
+	}
+}
+
+// distancesTo returns the number of moves from every key of the layout to the given one, going around gaps.
+func (f *moveFinder) distancesTo(to lib.Coord) map[lib.Coord]int {
+	if distances, ok := f.distances[to]; ok {
+		return distances
+	}
+
+	distances := map[lib.Coord]int{to: 0}
+	queue := []lib.Coord{to}
+	for len(queue) > 0 {
+		coord := queue[0]
+		queue = queue[1:]
+		for _, action := range moveActions {
+			nextCoord := coord.Add(lib.Actions[action])
+			if !lo.HasKey(f.layout, nextCoord) || lo.HasKey(distances, nextCoord) {
+				continue
+			}
+
+			distances[nextCoord] = distances[coord] + 1
+			queue = append(queue, nextCoord)
+		}
+	}
+	f.distances[to] = distances
+
+	return distances
+}
+
+// shortestMoves returns every shortest sequence of moves that takes an arm from one key of the layout to another
+// without crossing a gap, each followed by the final press. The returned sequences must not be modified.
+func (f *moveFinder) shortestMoves(from, to lib.Coord) [][]int {
+	key := [2]lib.Coord{from, to}
+	if allMoves, ok := f.moves[key]; ok {
+		return allMoves
+	}
+
+	distances := f.distancesTo(to)
+	var allMoves [][]int
+	switch {
+	case !lo.HasKey(distances, from):
+	case from == to:
+		allMoves = [][]int{{lib.Press}}
+	default:
+		for _, action := range moveActions {
+			nextCoord := from.Add(lib.Actions[action])
+			if dist, ok := distances[nextCoord]; !ok || dist != distances[from]-1 {
+				continue
+			}
+
+			for _, moves := range f.shortestMoves(nextCoord, to) {
+				allMoves = append(allMoves, append([]int{action}, moves...))
+			}
+		}
+	}
+	f.moves[key] = allMoves
+
+	return allMoves
+}
+
+func solveAndMultiply(
+	ctx context.Context, target string, solutionsCache map[string][]string, allMaps AllMaps, numIntermediateKeypads int,
+) (int64, error) {
+	solutionLength, err := solveKeyPad(ctx, target, solutionsCache, allMaps, numIntermediateKeypads)
+	if err != nil {
+		return -1, err
+	}
+
+	value, err := numericPart(target)
+	if err != nil {
+		return -1, err
+	}
+
+	return value * int64(solutionLength), nil
+}
+
+// numericPart returns the number formed by the decimal digits of the code, ignoring any other keys; codes without
+// digits are worth zero.
+func numericPart(target string) (int64, error) {
+	digits := strings.Map(func(r rune) rune {
+		if r < '0' || r > '9' {
+			return -1
+		}
+		return r
+	}, target)
+	if len(digits) < 1 {
+		return 0, nil
+	}
+
+	value, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return -1, fmt.Errorf("failed to parse numeric part of code `%s`: %w", target, err)
+	}
+
+	return value, nil
+}
+
+func solveKeyPad(
+	ctx context.Context, target string, solutionsCache map[string][]string, allMaps AllMaps, numIntermediateKeypads int,
+) (int, error) {
+	solutionsKeypad, err := doDijkstra(ctx, target, allMaps.NumPad.RunesByNumPadKey, allMaps.NumPad.Layout, allMaps.NumPad.RevLayout[allMaps.NumPad.StartKey])
+	if err != nil {
+		return -1, err
+	}
+	slog.Debug("keypad solutions", "solutions", len(solutionsKeypad))
+	if len(solutionsKeypad) < 1 {
+		return -1, fmt.Errorf("no solution found for code `%s`", target)
+	}
+
+	solutionLength, err := solveActionPad(ctx, solutionsCache, allMaps, numIntermediateKeypads, solutionsKeypad)
+	if err != nil {
+		return -1, err
+	}
+	return solutionLength, nil
+}
+
+func solveActionPad(
+	ctx context.Context, solutionsCache map[string][]string, allMaps AllMaps, numIntermediateKeypads int,
+	solutionsKeypad []string,
+) (int, error) {
+	minLengthKeypad := lo.Min(lo.Map(solutionsKeypad, func(s string, _ int) int {
+		return len(s)
+	}))
+	filteredSolutionsKeypad := lo.Filter(solutionsKeypad, func(s string, _ int) bool {
+		return len(s) == minLengthKeypad
+	})
+	slog.Debug("keypad solutions after filtering", "solutions", len(filteredSolutionsKeypad))
+
+	minLengthPrev := minLengthKeypad
+	filteredSolutionsPrev := filteredSolutionsKeypad
+	sizeOfCache := len(solutionsCache)
+	for iKeyPad := numIntermediateKeypads; iKeyPad > 0; iKeyPad-- {
+		solutionsNext := set.New[string](0)
+		for _, solutionPrev := range filteredSolutionsPrev {
+			subSolutionsNext, err := doActionDijkstra(ctx, solutionPrev, solutionsCache, allMaps.ActionPad.RunesByAction, allMaps.ActionPad.Layout, allMaps.ActionPad.RevLayout[lib.Press])
+			if err != nil {
+				return -1, err
+			}
+
+			subSolutionsNextWithCache := set.New[string](len(subSolutionsNext))
+			subSolutionsNextWithCache.InsertSlice(subSolutionsNext)
+			subSolutionsNextWithCache.InsertSlice(lo.ValueOr(solutionsCache, solutionPrev, []string{}))
+			subSolutionsNextWithCacheSlice := subSolutionsNextWithCache.Slice()
+			minLengthNext := lo.Min(lo.Map(subSolutionsNextWithCacheSlice, func(s string, _ int) int {
+				return len(s)
+			}))
+			filteredSubSolutionsNext := lo.Filter(subSolutionsNextWithCacheSlice, func(s string, _ int) bool {
+				return len(s) == minLengthNext
+			})
+
+			solutionsCache[solutionPrev] = filteredSubSolutionsNext
+			newSizeOfCache := len(solutionsCache)
+			if newSizeOfCache > sizeOfCache {
+				sizeOfCache = newSizeOfCache
+				slog.Debug("solutions cache", "round", iKeyPad, "size", sizeOfCache)
+			}
+			solutionsNext.InsertSlice(filteredSubSolutionsNext)
+		}
+		slog.Debug("next solutions", "round", iKeyPad, "solutions", solutionsNext.Size())
+		if solutionsNext.Size() < 1 {
+			return -1, errors.New("no solution found on action pad")
+		}
+
+		solutionsNextSlice := solutionsNext.Slice()
+		minLengthNext := lo.Min(lo.Map(solutionsNextSlice, func(s string, _ int) int {
+			return len(s)
+		}))
+		filteredSolutionsNext := lo.Filter(solutionsNextSlice, func(s string, _ int) bool {
+			return len(s) == minLengthNext
+		})
+		slog.Debug("next solutions after filtering", "round", iKeyPad, "solutions", len(filteredSolutionsNext))
+
+		minLengthPrev = minLengthNext
+		filteredSolutionsPrev = filteredSolutionsNext
+	}
+
+	return minLengthPrev, nil
+}
+
+func doActionDijkstra(
+	ctx context.Context, targetString string, solutionsCache map[string][]string, runesByKey map[int]rune,
+	nextLayout map[lib.Coord]int, initialCoord lib.Coord,
+) ([]string, error) {
+	origTargetString := targetString
+	var preSolutions [][]string
+	prefixFound := true
+	for prefixFound && len(targetString) > 0 {
+		prefixFound = false
+		for prefix, solutions := range solutionsCache {
+			if strings.HasPrefix(targetString, prefix) {
+				preSolutions = append(preSolutions, solutions)
+				targetString = targetString[len(prefix):]
+				prefixFound = true
+			}
+		}
+	}
+
+	allSolutions := preSolutions
+	if len(targetString) > 0 {
+		solutions, err := doDijkstra(ctx, targetString, runesByKey, nextLayout, initialCoord)
+		if err != nil {
+			return nil, err
+		}
+
+		allSolutions = append(allSolutions, solutions)
+	}
+
+	product := lo.Reduce(allSolutions, func(agg []string, item []string, _ int) []string {
+		return cartesianConcat(agg, item)
+	}, []string{""})
+
+	// Stitching cached prefixes together must give the same solutions as searching for the whole string; checking that
+	// costs another search, so it is only done when debugging.
+	if len(allSolutions) > 1 && logging.DebugEnabled() {
+		solutions, err := doDijkstra(ctx, origTargetString, runesByKey, nextLayout, initialCoord)
+		if err != nil {
+			return nil, err
+		}
+
+		slices.Sort(product)
+		slices.Sort(solutions)
+		if !slices.Equal(solutions, product) {
+			return nil, fmt.Errorf("internal error: answers are not equal: %v != %v", solutions, product)
+		}
+	}
+
+	return product, nil
+}
+
+func cartesianConcat(slice1, slice2 []string) []string {
+	var result []string
+	for _, s1 := range slice1 {
+		for _, s2 := range slice2 {
+			result = append(result, s1+s2)
+		}
+	}
+	return result
+}
+
+func doDijkstra(
+	ctx context.Context, targetString string, runesByKey map[int]rune, nextLayout map[lib.Coord]int,
+	initialCoord lib.Coord,
+) ([]string, error) {
+	initState := State{
+		NextCoord: initialCoord,
+	}
+	bestCostByState := make(map[State]int)
+	unvisitedQueue := pq.New[State, float64](pq.MinHeap)
+	unvisitedQueue.Put(initState, 0)
+	removed := set.New[State](0)
+	results := make([]string, 0)
+	for !unvisitedQueue.IsEmpty() {
+		err := ctx.Err()
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Toy code
+		}
+
+		item := unvisitedQueue.Get()
+		state, _ := item.Value, item.Priority
+
+		removed.Insert(state)
+		if state.NextStr == targetString {
+			results = append(results, state.CurrentStr)
+			continue
+		}
+
+		for nextRune := range lib.ActionByRune {
+			newState := state
+			var action int
+			var err error
+			action, err = processAction(
+				nextRune,
+				&newState.CurrentStr,
+				&newState.NextCoord,
+				nextLayout,
+			)
+			if errors.Is(err, errOutOfBounds) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+
+			if action != lib.InvalidNumPadKey {
+				nextRune = runesByKey[action]
+				newState.NextStr += string(nextRune)
+			}
+
+			if !strings.HasPrefix(targetString, newState.NextStr) {
+				continue
+			}
+
+			newCost := len(newState.CurrentStr)
+			stateKey := State{
+				CurrentStr: "",
+				NextCoord:  newState.NextCoord,
+				NextStr:    newState.NextStr,
+			}
+			if oldBest, ok := bestCostByState[stateKey]; ok {
+				switch {
+				case newCost < oldBest:
+					unvisitedQueue.Update(newState, float64(newCost))
+				case newCost > oldBest:
+					newCost = oldBest
+				default:
+					unvisitedQueue.Put(newState, float64(newCost))
+				}
+			} else {
+				unvisitedQueue.Put(newState, float64(newCost))
+			}
+			bestCostByState[stateKey] = newCost
+		}
+	}
+
+	return results, nil
+}
+
+func processAction(r rune, currentStr *string, nextCoords *lib.Coord, nextLayout map[lib.Coord]int) (int, error) {
+	*currentStr += string(r)
+	action := lib.ActionByRune[r]
+	switch action {
+	case lib.Press:
+		newAction := nextLayout[*nextCoords]
+		return newAction, nil
+	default:
+		*nextCoords = nextCoords.Add(lib.Actions[action])
+		if !lo.HasKey(nextLayout, *nextCoords) {
+			return lib.InvalidAction, errOutOfBounds
+		}
+		return lib.InvalidAction, nil
+	}
+}
+
+func makeActionPadMaps(chain *lib.KeypadChain) ActionPadMaps {
+	actionPadMaps := ActionPadMaps{}
+	actionPadMaps.Layout = make(ActionPadLayoutMap)
+	for iRow, row := range chain.ActionPadLayout {
+		for iCol, key := range row {
+			if key == lib.InvalidAction {
+				continue
+			}
+
+			actionPadMaps.Layout[lib.Coord{Row: iRow, Col: iCol}] = key
+		}
+	}
+
+	actionPadMaps.RevLayout = make(ActionPadRevLayoutMap)
+	for iRow, row := range chain.ActionPadLayout {
+		for iCol, key := range row {
+			if key == lib.InvalidAction {
+				continue
+			}
+
+			actionPadMaps.RevLayout[key] = lib.Coord{Row: iRow, Col: iCol}
+		}
+	}
+
+	actionPadMaps.RunesByAction = lo.Invert(lib.ActionByRune)
+
+	return actionPadMaps
+}
+
+func makeNumPadMaps(chain *lib.KeypadChain) NumPadMaps {
+	numPadMaps := NumPadMaps{}
+	numPadMaps.Layout = make(NumPadLayoutMap)
+	for iRow, row := range chain.NumPadLayout {
+		for iCol, key := range row {
+			if key == lib.InvalidNumPadKey {
+				continue
+			}
+
+			numPadMaps.Layout[lib.Coord{Row: iRow, Col: iCol}] = key
+		}
+	}
+
+	numPadMaps.RevLayout = make(NumPadRevLayoutMap)
+	for iRow, row := range chain.NumPadLayout {
+		for iCol, key := range row {
+			if key == lib.InvalidNumPadKey {
+				continue
+			}
+
+			numPadMaps.RevLayout[key] = lib.Coord{Row: iRow, Col: iCol}
+		}
+	}
+
+	numPadMaps.RunesByNumPadKey = lo.Invert(chain.NumPadKeyByRune)
+	numPadMaps.StartKey = chain.NumPadStartKey
+
+	return numPadMaps
+}
+
+func readLayoutFile(args Args) (chain *lib.KeypadChain, err error) {
+	if args.LayoutFile == "" {
+		return lib.DefaultKeypadChain(), nil
+	}
+
+	file, err := os.Open(args.LayoutFile)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Toy code
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	return lib.ReadKeypadChain(bufio.NewScanner(file)) //nolint:wrapcheck // Toy code
+}
+
+func readInput(input io.Reader, chain *lib.KeypadChain, out *report.Report) ([]lib.NumPadCode, error) {
+	scanner := bufio.NewScanner(out.CountInput(input))
+	numPadCodes, err := lib.ReadInput(scanner, chain.NumPadKeyByRune)
+
+	return numPadCodes, err //nolint:wrapcheck // Toy code
+}
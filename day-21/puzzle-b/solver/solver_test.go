@@ -0,0 +1,132 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"common/parsing"
+	"common/report"
+	"daytwentyone/b/lib"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var exampleCodes = []string{"029A", "980A", "179A", "456A", "379A"}
+
+func defaultMaps() AllMaps {
+	chain := lib.DefaultKeypadChain()
+	return AllMaps{NumPad: makeNumPadMaps(chain), ActionPad: makeActionPadMaps(chain)}
+}
+
+func TestShortestMoves(t *testing.T) {
+	allMaps := defaultMaps()
+	numPad := newMoveFinder(allMaps.NumPad.Layout)
+	revLayout := allMaps.NumPad.RevLayout
+
+	// From A to 7 takes 3 moves up and 2 left, in any order but the one starting with both moves left into the gap.
+	allMoves := numPad.shortestMoves(revLayout[lib.NumPadKeyA], revLayout[lib.NumPadKey7])
+	assert.Len(t, allMoves, 9)
+	for _, moves := range allMoves {
+		assert.Len(t, moves, 6)
+		assert.Equal(t, lib.Press, moves[5])
+		assert.NotEqual(t, []int{lib.MoveLeft, lib.MoveLeft}, moves[:2])
+	}
+	assert.Equal(t, [][]int{{lib.Press}}, numPad.shortestMoves(revLayout[lib.NumPadKey5], revLayout[lib.NumPadKey5]))
+	assert.Equal(t, [][]int{{lib.MoveUp, lib.Press}}, numPad.shortestMoves(revLayout[lib.NumPadKey0], revLayout[lib.NumPadKey2]))
+
+	// The gap reaches nothing.
+	assert.Empty(t, numPad.shortestMoves(lib.Coord{Row: 3, Col: 0}, revLayout[lib.NumPadKey2]))
+}
+
+func TestLengthSolver(t *testing.T) {
+	tests := []struct {
+		depth int
+		total int64
+	}{
+		{depth: 0, total: 12*29 + 12*980 + 14*179 + 12*456 + 14*379},
+		{depth: 2, total: 126384},
+		{depth: 25, total: 154115708116294},
+	}
+	for _, test := range tests {
+		lengths := newLengthSolver(defaultMaps())
+		total := int64(0)
+		for _, code := range exampleCodes {
+			value, err := lengths.solveAndMultiply(context.Background(), code, test.depth)
+			require.NoError(t, err)
+			total += value.Int64()
+		}
+		assert.Equal(t, test.total, total, "depth %d", test.depth)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	two := 2
+	file, err := os.Open("../../input/input.txt")
+	require.NoError(t, err)
+	defer file.Close()
+	out := report.New(report.JSON)
+	require.NoError(t, Solve(context.Background(), Args{NumIntermediateKeypads: &two}, file, out))
+	assert.Equal(t, "134120", fmt.Sprint(out.Answers()["total"]))
+
+	// The strings mode agrees at depth 1, and refuses deeper chains than it can handle.
+	one := 1
+	lengthsOut := report.New(report.JSON)
+	require.NoError(t, Solve(context.Background(), Args{NumIntermediateKeypads: &one}, exampleInput(), lengthsOut))
+	stringsOut := report.New(report.JSON)
+	require.NoError(t, Solve(context.Background(), Args{NumIntermediateKeypads: &one, Strings: true}, exampleInput(), stringsOut))
+	assert.Equal(t, fmt.Sprint(lengthsOut.Answers()["total"]), fmt.Sprint(stringsOut.Answers()["total"]))
+
+	three := 3
+	err = Solve(context.Background(), Args{NumIntermediateKeypads: &three, Strings: true}, exampleInput(), report.New(report.JSON))
+	assert.ErrorContains(t, err, "limited to 2 intermediate keypads")
+}
+
+func exampleInput() *strings.Reader {
+	return strings.NewReader(strings.Join(exampleCodes, "\n") + "\n")
+}
+
+// layoutTests type code 12A on a single row num pad, where the robot presses <<A>A>A, worth 7 presses directly.
+var layoutTests = []struct {
+	name   string
+	layout string
+	total  int64
+}{
+	{name: "no robot", layout: "depth 0\nnumpad\n12A\n", total: 7 * 12},
+	// v<<A A >>^A vA ^A vA ^A on the default action pad.
+	{name: "default action pad", layout: "depth 1\nnumpad\n12A\n", total: 17 * 12},
+	// <<<<A A >>>>A <A >A <A >A on an action pad of a single row.
+	{name: "single row action pad", layout: "depth 1\nnumpad\n12A\n\nactionpad\n<^v>A\n", total: 19 * 12},
+}
+
+func writeLayout(t *testing.T, layout string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "layout.txt")
+	require.NoError(t, os.WriteFile(path, []byte(layout), 0o600))
+	return path
+}
+
+func TestSolveLayout(t *testing.T) {
+	for _, test := range layoutTests {
+		t.Run(test.name, func(t *testing.T) {
+			// Both modes agree, as the chains are shallow.
+			for _, useStrings := range []bool{false, true} {
+				out := report.New(report.JSON)
+				args := Args{LayoutFile: writeLayout(t, test.layout), Strings: useStrings}
+				require.NoError(t, Solve(context.Background(), args, strings.NewReader("12A\n"), out))
+				assert.Equal(t, fmt.Sprint(test.total), fmt.Sprint(out.Answers()["total"]), "strings: %v", useStrings)
+			}
+		})
+	}
+
+	path := writeLayout(t, "actionpad\n^..A\n<v>..\n")
+	err := Solve(context.Background(), Args{LayoutFile: path}, strings.NewReader("12A\n"), report.New(report.JSON))
+	var parseErr *parsing.Error
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, path, parseErr.File)
+	assert.ErrorContains(t, err, "action pad key cannot be reached")
+}
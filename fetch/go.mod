@@ -0,0 +1,12 @@
+module fetch
+
+go 1.23.4
+
+require (
+	common v0.0.0-00010101000000-000000000000
+	github.com/alexflint/go-arg v1.5.1
+)
+
+require github.com/alexflint/go-scalar v1.2.0 // indirect
+
+replace common => ../common
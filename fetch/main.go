@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"log/slog"
+	"time"
+
+	"common/input"
+	"common/logging"
+
+	"github.com/alexflint/go-arg"
+)
+
+type Args struct {
+	Days     []int         `arg:"positional,required"                                               help:"days whose input to download"`
+	Session  string        `arg:"--session,env:AOC_SESSION"                                         help:"session cookie of the puzzle website"`
+	CacheDir string        `arg:"--cache-dir,env:AOC_CACHE_DIR"                                     help:"directory to keep inputs in (defaults to aoc in the user cache directory)"`
+	Interval time.Duration `arg:"--interval"                    default:"15s"                      help:"minimum time between two requests to the website, across runs"`
+	BaseURL  string        `arg:"--base-url"                    default:"https://adventofcode.com" help:"address of the puzzle website"`
+
+	logging.Flags
+}
+
+func main() {
+	var args Args
+	arg.MustParse(&args)
+	logging.Setup(args.Flags)
+
+	if args.CacheDir == "" {
+		cacheDir, err := input.CacheDir()
+		if err != nil {
+			log.Fatal(err) //nolint:revive // Toy code
+		}
+		args.CacheDir = cacheDir
+	}
+
+	fetcher := input.NewFetcher(args.Session, args.CacheDir)
+	fetcher.Interval = args.Interval
+	fetcher.BaseURL = args.BaseURL
+	for _, day := range args.Days {
+		path, err := fetcher.Fetch(context.Background(), day)
+		if errors.Is(err, input.ErrCached) {
+			slog.Info("already cached, not downloading it again", "day", day, "path", path)
+			continue
+		}
+		if err != nil {
+			log.Fatal(err) //nolint:revive // Toy code
+		}
+		slog.Info("fetched", "day", day, "path", path)
+	}
+}
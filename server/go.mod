@@ -0,0 +1,150 @@
+module server
+
+go 1.23.4
+
+require (
+	common v0.0.0-00010101000000-000000000000
+	dayeight/a v0.0.0-00010101000000-000000000000
+	dayeight/b v0.0.0-00010101000000-000000000000
+	dayeighteen/a v0.0.0-00010101000000-000000000000
+	dayeighteen/b v0.0.0-00010101000000-000000000000
+	dayeleven/a v0.0.0-00010101000000-000000000000
+	dayeleven/b v0.0.0-00010101000000-000000000000
+	dayfifteen/a v0.0.0-00010101000000-000000000000
+	dayfifteen/b v0.0.0-00010101000000-000000000000
+	dayfive/a v0.0.0-00010101000000-000000000000
+	dayfive/b v0.0.0-00010101000000-000000000000
+	dayfour/a v0.0.0-00010101000000-000000000000
+	dayfour/b v0.0.0-00010101000000-000000000000
+	dayfourteen/a v0.0.0-00010101000000-000000000000
+	dayfourteen/b v0.0.0-00010101000000-000000000000
+	daynine/a v0.0.0-00010101000000-000000000000
+	daynine/b v0.0.0-00010101000000-000000000000
+	daynineteen/a v0.0.0-00010101000000-000000000000
+	daynineteen/b v0.0.0-00010101000000-000000000000
+	dayone/a v0.0.0-00010101000000-000000000000
+	dayone/b v0.0.0-00010101000000-000000000000
+	dayseven/a v0.0.0-00010101000000-000000000000
+	dayseven/b v0.0.0-00010101000000-000000000000
+	dayseventeen/a v0.0.0-00010101000000-000000000000
+	dayseventeen/b v0.0.0-00010101000000-000000000000
+	daysix/a v0.0.0-00010101000000-000000000000
+	daysix/b v0.0.0-00010101000000-000000000000
+	daysixteen/a v0.0.0-00010101000000-000000000000
+	daysixteen/b v0.0.0-00010101000000-000000000000
+	dayten/a v0.0.0-00010101000000-000000000000
+	dayten/b v0.0.0-00010101000000-000000000000
+	daythirteen/a v0.0.0-00010101000000-000000000000
+	daythirteen/b v0.0.0-00010101000000-000000000000
+	daythree/a v0.0.0-00010101000000-000000000000
+	daythree/b v0.0.0-00010101000000-000000000000
+	daytwelve/a v0.0.0-00010101000000-000000000000
+	daytwelve/b v0.0.0-00010101000000-000000000000
+	daytwenty/a v0.0.0-00010101000000-000000000000
+	daytwenty/b v0.0.0-00010101000000-000000000000
+	daytwentyone/a v0.0.0-00010101000000-000000000000
+	daytwentyone/b v0.0.0-00010101000000-000000000000
+	daytwo/a v0.0.0-00010101000000-000000000000
+	daytwo/b v0.0.0-00010101000000-000000000000
+	github.com/alexflint/go-arg v1.6.1
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
+	github.com/alexflint/go-scalar v1.2.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/hashicorp/go-set/v3 v3.0.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/samber/lo v1.47.0 // indirect
+	github.com/tiendc/go-deepcopy v1.2.0 // indirect
+	golang.org/x/exp v0.0.0-20241217172543-b2144cdd0a67 // indirect
+	golang.org/x/text v0.16.0 // indirect
+	gopkg.in/dnaeon/go-priorityqueue.v1 v1.1.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace common => ../common
+
+replace dayone/a => ../day-01/puzzle-a/src
+
+replace dayone/b => ../day-01/puzzle-b/src
+
+replace daytwo/a => ../day-02/puzzle-a/src
+
+replace daytwo/b => ../day-02/puzzle-b/src
+
+replace daythree/a => ../day-03/puzzle-a/src
+
+replace daythree/b => ../day-03/puzzle-b/src
+
+replace dayfour/a => ../day-04/puzzle-a/src
+
+replace dayfour/b => ../day-04/puzzle-b/src
+
+replace dayfive/a => ../day-05/puzzle-a/src
+
+replace dayfive/b => ../day-05/puzzle-b/src
+
+replace daysix/a => ../day-06/puzzle-a/src
+
+replace daysix/b => ../day-06/puzzle-b/src
+
+replace dayseven/a => ../day-07/puzzle-a/src
+
+replace dayseven/b => ../day-07/puzzle-b/src
+
+replace dayeight/a => ../day-08/puzzle-a
+
+replace dayeight/b => ../day-08/puzzle-b
+
+replace daynine/a => ../day-09/puzzle-a
+
+replace daynine/b => ../day-09/puzzle-b
+
+replace dayten/a => ../day-10/puzzle-a
+
+replace dayten/b => ../day-10/puzzle-b
+
+replace dayeleven/a => ../day-11/puzzle-a
+
+replace dayeleven/b => ../day-11/puzzle-b
+
+replace daytwelve/a => ../day-12/puzzle-a
+
+replace daytwelve/b => ../day-12/puzzle-b
+
+replace daythirteen/a => ../day-13/puzzle-a
+
+replace daythirteen/b => ../day-13/puzzle-b
+
+replace dayfourteen/a => ../day-14/puzzle-a
+
+replace dayfourteen/b => ../day-14/puzzle-b
+
+replace dayfifteen/a => ../day-15/puzzle-a
+
+replace dayfifteen/b => ../day-15/puzzle-b
+
+replace daysixteen/a => ../day-16/puzzle-a
+
+replace daysixteen/b => ../day-16/puzzle-b
+
+replace dayseventeen/a => ../day-17/puzzle-a
+
+replace dayseventeen/b => ../day-17/puzzle-b
+
+replace dayeighteen/a => ../day-18/puzzle-a
+
+replace dayeighteen/b => ../day-18/puzzle-b
+
+replace daynineteen/a => ../day-19/puzzle-a
+
+replace daynineteen/b => ../day-19/puzzle-b
+
+replace daytwenty/a => ../day-20/puzzle-a
+
+replace daytwenty/b => ../day-20/puzzle-b
+
+replace daytwentyone/a => ../day-21/puzzle-a
+
+replace daytwentyone/b => ../day-21/puzzle-b
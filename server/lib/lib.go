@@ -0,0 +1,264 @@
+// Package lib serves the puzzle solvers over HTTP, running them in process with a timeout and a concurrency limit.
+package lib
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"common/parsing"
+	"common/report"
+
+	"github.com/alexflint/go-arg"
+)
+
+// MaxInputBytes bounds the request body, which is read whole before the solver starts.
+const MaxInputBytes = 16 << 20
+
+// Puzzle identifies one part of one day, e.g. {Day: 7, Part: 2}.
+type Puzzle struct {
+	Day  int
+	Part int
+}
+
+func (p Puzzle) String() string {
+	return fmt.Sprintf("day-%02d/puzzle-%c", p.Day, 'a'+p.Part-1)
+}
+
+// Solver runs a puzzle on the input, taking the query parameters of the request as its options. It stops early with the
+// context's error once ctx is cancelled.
+type Solver func(ctx context.Context, query map[string][]string, input io.Reader, out *report.Report) error
+
+// Entry adapts a day's solver.Solve, parsing the query into its Args with go-arg so that options mean what they mean on
+// the command line. Only the allowed options and `arg` parameters are accepted: options naming files or writing to
+// stdout must stay off the server, which reads the input from the request and answers with the report alone.
+func Entry[A any](solve func(context.Context, A, io.Reader, *report.Report) error, allowed ...string) Solver {
+	return func(ctx context.Context, query map[string][]string, input io.Reader, out *report.Report) error {
+		for key := range query {
+			if key != "arg" && !slices.Contains(allowed, key) {
+				return &OptionsError{Err: fmt.Errorf("option %q is not accepted", key)}
+			}
+		}
+
+		var args A
+		parser, err := arg.NewParser(arg.Config{Program: "solver", IgnoreEnv: true}, &args)
+		if err != nil {
+			return err //nolint:wrapcheck // Toy code
+		}
+		err = parser.Parse(Options(query))
+		if err != nil {
+			return &OptionsError{Err: err}
+		}
+
+		return solve(ctx, args, input, out)
+	}
+}
+
+// OptionsError reports query parameters the solver does not accept.
+type OptionsError struct {
+	Err error
+}
+
+func (e *OptionsError) Error() string {
+	return "invalid options: " + e.Err.Error()
+}
+
+func (e *OptionsError) Unwrap() error {
+	return e.Err
+}
+
+// Options turns the query parameters into command line arguments: every parameter but `arg` becomes an option, e.g.
+// `?n=75&verbose` gives `--n=75 --verbose`, and `arg` parameters become positional arguments after `--` and a
+// placeholder for the input file, which the request body stands in for, so that they are never taken for options.
+// Options are sorted so that the same query always gives the same arguments.
+func Options(query map[string][]string) []string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		if key != "arg" {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var options []string
+	for _, key := range keys {
+		for _, value := range query[key] {
+			if value == "" {
+				options = append(options, "--"+key)
+			} else {
+				options = append(options, "--"+key+"="+value)
+			}
+		}
+	}
+	options = append(options, "--", "-")
+
+	return append(options, query["arg"]...)
+}
+
+// ParsePuzzle reads the path values of a request; days may be zero-padded and parts given as 1 and 2 or a and b.
+func ParsePuzzle(day, part string) (Puzzle, bool) {
+	numDay, err := strconv.Atoi(day)
+	if err != nil {
+		return Puzzle{}, false
+	}
+
+	switch strings.ToLower(part) {
+	case "1", "a":
+		return Puzzle{Day: numDay, Part: 1}, true
+	case "2", "b":
+		return Puzzle{Day: numDay, Part: 2}, true
+	default:
+		return Puzzle{}, false
+	}
+}
+
+type Server struct {
+	solvers map[Puzzle]Solver
+	timeout time.Duration
+	slots   chan struct{}
+}
+
+// New makes a server running at most maxConcurrent solvers at a time. A solver still running when its request times
+// out is cancelled through its context, and keeps its slot until it notices and returns.
+func New(solvers map[Puzzle]Solver, timeout time.Duration, maxConcurrent int) *Server {
+	return &Server{solvers: solvers, timeout: timeout, slots: make(chan struct{}, max(maxConcurrent, 1))}
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /days/{day}/parts/{part}", s.solve)
+
+	return mux
+}
+
+type errorBody struct {
+	Error string `json:"error"`
+	Line  int    `json:"line,omitempty"`
+	Col   int    `json:"col,omitempty"`
+}
+
+// outcome is what a solver run gave; internal marks a panic or a failure to write the report, which are no fault of the
+// request.
+type outcome struct {
+	body     []byte
+	err      error
+	internal bool
+}
+
+func (s *Server) solve(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	puzzle, ok := ParsePuzzle(r.PathValue("day"), r.PathValue("part"))
+	solver, found := s.solvers[puzzle]
+	if !ok || !found {
+		writeError(w, http.StatusNotFound, errorBody{Error: "no such puzzle"})
+		return
+	}
+
+	input, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxInputBytes))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeError(w, http.StatusRequestEntityTooLarge, errorBody{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errorBody{Error: err.Error()})
+		return
+	}
+	query := r.URL.Query()
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+	defer cancel()
+
+	select {
+	case s.slots <- struct{}{}:
+	case <-ctx.Done():
+		writeError(w, http.StatusServiceUnavailable, errorBody{Error: "too many puzzles being solved, try again later"})
+		return
+	}
+
+	done := make(chan outcome, 1)
+	go func() {
+		defer func() { <-s.slots }()
+		done <- run(ctx, solver, query, input)
+	}()
+
+	// A solver failing once ctx is done has most likely just noticed the timeout, so it gets the same answer.
+	select {
+	case result := <-done:
+		if result.err == nil || ctx.Err() == nil {
+			status := respond(w, result)
+			slog.Info("request", "puzzle", puzzle, "status", status, "elapsed", time.Since(start))
+			return
+		}
+	case <-ctx.Done():
+	}
+	writeError(w, http.StatusGatewayTimeout, errorBody{Error: "solver did not finish within " + s.timeout.String()})
+	slog.Warn("timed out", "puzzle", puzzle, "timeout", s.timeout)
+}
+
+// run turns a panic into an outcome, as one bad input must not take down the solvers running for other requests.
+func run(ctx context.Context, solver Solver, query map[string][]string, input []byte) (result outcome) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			slog.Error("solver panicked", "panic", recovered)
+			result = outcome{err: fmt.Errorf("%v", recovered), internal: true}
+		}
+	}()
+
+	out := report.New(report.JSON)
+	err := solver(ctx, query, bytes.NewReader(input), out)
+	if err != nil {
+		return outcome{err: err}
+	}
+
+	var body bytes.Buffer
+	err = out.Write(&body)
+	if err != nil {
+		return outcome{err: err, internal: true}
+	}
+
+	return outcome{body: body.Bytes()}
+}
+
+// respond maps the solver's errors to statuses: anything the solver returns comes from the input or the options, so it
+// is a bad request, with the location when the error has one.
+func respond(w http.ResponseWriter, result outcome) int {
+	var parseErr *parsing.Error
+	switch {
+	case result.internal:
+		writeError(w, http.StatusInternalServerError, errorBody{Error: result.err.Error()})
+		return http.StatusInternalServerError
+	case errors.As(result.err, &parseErr):
+		writeError(w, http.StatusBadRequest, errorBody{Error: result.err.Error(), Line: parseErr.Line, Col: parseErr.Col})
+		return http.StatusBadRequest
+	case result.err != nil:
+		writeError(w, http.StatusBadRequest, errorBody{Error: result.err.Error()})
+		return http.StatusBadRequest
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err := w.Write(result.body)
+	if err != nil {
+		slog.Debug("could not write response", "error", err)
+	}
+
+	return http.StatusOK
+}
+
+func writeError(w http.ResponseWriter, status int, body errorBody) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(body)
+	if err != nil {
+		slog.Debug("could not write response", "error", err)
+	}
+}
@@ -0,0 +1,132 @@
+package lib
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"common/parsing"
+	"common/report"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeArgs struct {
+	InputFile string `arg:"positional,required"`
+	Extra     string `arg:"positional"`
+	Steps     int    `arg:"-n, --steps" default:"1"`
+}
+
+// fakeSolve counts the lines of the input, times the steps, and fails on a line reading `bad`.
+func fakeSolve(_ context.Context, args fakeArgs, input io.Reader, out *report.Report) error {
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	for iLine, line := range lines {
+		if line == "bad" {
+			return fmt.Errorf("reading input: %w", parsing.Errorf(iLine+1, 1, line, "bad input"))
+		}
+	}
+	out.Answer("lines", len(lines)*args.Steps)
+	out.Answer("extra", args.Extra)
+
+	return nil
+}
+
+func post(t *testing.T, server *Server, path, body string) (int, map[string]any) {
+	t.Helper()
+	request := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, request)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &decoded))
+
+	return recorder.Code, decoded
+}
+
+func TestOptions(t *testing.T) {
+	options := Options(map[string][]string{"verbose": {""}, "arg": {"0.5", "--n=1"}, "n": {"75"}, "layout": {"a.txt"}})
+	assert.Equal(t, []string{"--layout=a.txt", "--n=75", "--verbose", "--", "-", "0.5", "--n=1"}, options)
+}
+
+func TestParsePuzzle(t *testing.T) {
+	puzzle, ok := ParsePuzzle("07", "b")
+	assert.True(t, ok)
+	assert.Equal(t, Puzzle{Day: 7, Part: 2}, puzzle)
+	assert.Equal(t, "day-07/puzzle-b", puzzle.String())
+
+	puzzle, ok = ParsePuzzle("21", "1")
+	assert.True(t, ok)
+	assert.Equal(t, Puzzle{Day: 21, Part: 1}, puzzle)
+
+	_, ok = ParsePuzzle("x", "1")
+	assert.False(t, ok)
+	_, ok = ParsePuzzle("1", "3")
+	assert.False(t, ok)
+}
+
+func TestSolve(t *testing.T) {
+	server := New(map[Puzzle]Solver{{Day: 1, Part: 1}: Entry(fakeSolve, "n")}, time.Second, 1)
+
+	status, body := post(t, server, "/days/01/parts/a?n=3&arg=x", "1\n2\n")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]any{"lines": 6.0, "extra": "x"}, body["answers"])
+
+	status, body = post(t, server, "/days/1/parts/1", "1\nbad\n")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, map[string]any{"error": `reading input: <input>:2:1: bad input: "bad"`, "line": 2.0, "col": 1.0}, body)
+
+	// Only the allowed options are taken, even those the solver knows; positional arguments are never options.
+	status, body = post(t, server, "/days/1/parts/1?steps=2", "1\n")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, `invalid options: option "steps" is not accepted`, body["error"])
+	status, body = post(t, server, "/days/1/parts/1?arg=--steps=2", "1\n")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]any{"lines": 1.0, "extra": "--steps=2"}, body["answers"])
+	status, body = post(t, server, "/days/1/parts/1?n=x", "1\n")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body["error"], "invalid options: ")
+
+	status, _ = post(t, server, "/days/1/parts/2", "1\n")
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestSolveErrors(t *testing.T) {
+	server := New(map[Puzzle]Solver{
+		{Day: 1, Part: 1}: func(ctx context.Context, _ map[string][]string, _ io.Reader, _ *report.Report) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		{Day: 1, Part: 2}: func(_ context.Context, _ map[string][]string, _ io.Reader, _ *report.Report) error {
+			panic("index out of range")
+		},
+		{Day: 2, Part: 1}: func(_ context.Context, _ map[string][]string, _ io.Reader, _ *report.Report) error {
+			return errors.New("number of steps must be at least 1; got 0")
+		},
+	}, 50*time.Millisecond, 1)
+
+	status, body := post(t, server, "/days/1/parts/2", "")
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.Equal(t, "index out of range", body["error"])
+
+	status, body = post(t, server, "/days/2/parts/1", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, map[string]any{"error": "number of steps must be at least 1; got 0"}, body)
+
+	// The first solver is cancelled when it times out, which frees its slot for the second.
+	status, _ = post(t, server, "/days/1/parts/1", "")
+	assert.Equal(t, http.StatusGatewayTimeout, status)
+	status, _ = post(t, server, "/days/2/parts/1", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+}
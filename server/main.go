@@ -0,0 +1,131 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"log/slog"
+	"net/http"
+	"runtime"
+	"time"
+
+	"common/logging"
+	dayeighta "dayeight/a/solver"
+	dayeightb "dayeight/b/solver"
+	dayeighteena "dayeighteen/a/solver"
+	dayeighteenb "dayeighteen/b/solver"
+	dayelevena "dayeleven/a/solver"
+	dayelevenb "dayeleven/b/solver"
+	dayfifteena "dayfifteen/a/solver"
+	dayfifteenb "dayfifteen/b/solver"
+	dayfivea "dayfive/a/solver"
+	dayfiveb "dayfive/b/solver"
+	dayfoura "dayfour/a/solver"
+	dayfourb "dayfour/b/solver"
+	dayfourteena "dayfourteen/a/solver"
+	dayfourteenb "dayfourteen/b/solver"
+	dayninea "daynine/a/solver"
+	daynineb "daynine/b/solver"
+	daynineteena "daynineteen/a/solver"
+	daynineteenb "daynineteen/b/solver"
+	dayonea "dayone/a/solver"
+	dayoneb "dayone/b/solver"
+	daysevena "dayseven/a/solver"
+	daysevenb "dayseven/b/solver"
+	dayseventeena "dayseventeen/a/solver"
+	dayseventeenb "dayseventeen/b/solver"
+	daysixa "daysix/a/solver"
+	daysixb "daysix/b/solver"
+	daysixteena "daysixteen/a/solver"
+	daysixteenb "daysixteen/b/solver"
+	daytena "dayten/a/solver"
+	daytenb "dayten/b/solver"
+	daythirteena "daythirteen/a/solver"
+	daythirteenb "daythirteen/b/solver"
+	daythreea "daythree/a/solver"
+	daythreeb "daythree/b/solver"
+	daytwelvea "daytwelve/a/solver"
+	daytwelveb "daytwelve/b/solver"
+	daytwentya "daytwenty/a/solver"
+	daytwentyb "daytwenty/b/solver"
+	daytwentyonea "daytwentyone/a/solver"
+	daytwentyoneb "daytwentyone/b/solver"
+	daytwoa "daytwo/a/solver"
+	daytwob "daytwo/b/solver"
+	"server/lib"
+
+	"github.com/alexflint/go-arg"
+)
+
+type Args struct {
+	Address       string        `arg:"--address"        default:"127.0.0.1:8080" help:"address to listen on; query parameters become solver options"`
+	Timeout       time.Duration `arg:"--timeout"        default:"30s"            help:"time a request may take, waiting for a free slot included"`
+	MaxConcurrent int           `arg:"--max-concurrent"                          help:"number of solvers running at a time (defaults to the number of CPUs)"`
+
+	logging.Flags
+}
+
+// solvers lists every puzzle with the options it accepts; each day's Args are filled from the query parameters as the
+// command fills them from the command line. Options naming files or writing to stdout or stderr are left out.
+func solvers() map[lib.Puzzle]lib.Solver {
+	return map[lib.Puzzle]lib.Solver{
+		{Day: 1, Part: 1}:  lib.Entry(dayonea.Solve, "external", "chunk-size", "fan-in"),
+		{Day: 1, Part: 2}:  lib.Entry(dayoneb.Solve),
+		{Day: 2, Part: 1}:  lib.Entry(daytwoa.Solve),
+		{Day: 2, Part: 2}:  lib.Entry(daytwob.Solve),
+		{Day: 3, Part: 1}:  lib.Entry(daythreea.Solve),
+		{Day: 3, Part: 2}:  lib.Entry(daythreeb.Solve),
+		{Day: 4, Part: 1}:  lib.Entry(dayfoura.Solve),
+		{Day: 4, Part: 2}:  lib.Entry(dayfourb.Solve),
+		{Day: 5, Part: 1}:  lib.Entry(dayfivea.Solve),
+		{Day: 5, Part: 2}:  lib.Entry(dayfiveb.Solve),
+		{Day: 6, Part: 1}:  lib.Entry(daysixa.Solve),
+		{Day: 6, Part: 2}:  lib.Entry(daysixb.Solve),
+		{Day: 7, Part: 1}:  lib.Entry(daysevena.Solve),
+		{Day: 7, Part: 2}:  lib.Entry(daysevenb.Solve, "n"),
+		{Day: 8, Part: 1}:  lib.Entry(dayeighta.Solve),
+		{Day: 8, Part: 2}:  lib.Entry(dayeightb.Solve, "sparse", "ratio", "internal", "max-steps", "no-reduce-gcd", "forward", "pairs"),
+		{Day: 9, Part: 1}:  lib.Entry(dayninea.Solve),
+		{Day: 9, Part: 2}:  lib.Entry(daynineb.Solve),
+		{Day: 10, Part: 1}: lib.Entry(daytena.Solve, "encoding", "impassable", "trailhead-elevation", "peak-elevation"),
+		{Day: 10, Part: 2}: lib.Entry(daytenb.Solve, "climbs", "diagonal", "encoding", "impassable", "trailhead-elevation", "peak-elevation"),
+		{Day: 11, Part: 1}: lib.Entry(dayelevena.Solve, "n"),
+		{Day: 11, Part: 2}: lib.Entry(dayelevenb.Solve, "n", "engine", "histogram"),
+		{Day: 12, Part: 1}: lib.Entry(daytwelvea.Solve),
+		{Day: 12, Part: 2}: lib.Entry(daytwelveb.Solve),
+		{Day: 13, Part: 1}: lib.Entry(daythirteena.Solve, "max-steps"),
+		{Day: 13, Part: 2}: lib.Entry(daythirteenb.Solve),
+		{Day: 14, Part: 1}: lib.Entry(dayfourteena.Solve, "x-dimension", "y-dimension", "seconds"),
+		{Day: 14, Part: 2}: lib.Entry(dayfourteenb.Solve, "x-dimension", "y-dimension", "seconds"),
+		{Day: 15, Part: 1}: lib.Entry(dayfifteena.Solve),
+		{Day: 15, Part: 2}: lib.Entry(dayfifteenb.Solve),
+		{Day: 16, Part: 1}: lib.Entry(daysixteena.Solve),
+		{Day: 16, Part: 2}: lib.Entry(daysixteenb.Solve),
+		{Day: 17, Part: 1}: lib.Entry(dayseventeena.Solve),
+		{Day: 17, Part: 2}: lib.Entry(dayseventeenb.Solve),
+		{Day: 18, Part: 1}: lib.Entry(dayeighteena.Solve, "board-dim-rows", "board-dim-cols", "start-row", "start-col", "end-row", "end-col", "num-steps"),
+		{Day: 18, Part: 2}: lib.Entry(dayeighteenb.Solve, "board-dim-rows", "board-dim-cols", "start-row", "start-col", "end-row", "end-col"),
+		{Day: 19, Part: 1}: lib.Entry(daynineteena.Solve),
+		{Day: 19, Part: 2}: lib.Entry(daynineteenb.Solve, "enumerate", "sample", "seed"),
+		{Day: 20, Part: 1}: lib.Entry(daytwentya.Solve, "cheat-threshold"),
+		{Day: 20, Part: 2}: lib.Entry(daytwentyb.Solve, "depth", "threshold"),
+		{Day: 21, Part: 1}: lib.Entry(daytwentyonea.Solve),
+		{Day: 21, Part: 2}: lib.Entry(daytwentyoneb.Solve, "n", "strings"),
+	}
+}
+
+func main() {
+	var args Args
+	arg.MustParse(&args)
+	logging.Setup(args.Flags)
+	if args.MaxConcurrent < 1 {
+		args.MaxConcurrent = runtime.NumCPU()
+	}
+
+	server := lib.New(solvers(), args.Timeout, args.MaxConcurrent)
+	slog.Info("listening", "address", args.Address, "timeout", args.Timeout, "maxConcurrent", args.MaxConcurrent)
+	httpServer := &http.Server{Addr: args.Address, Handler: server.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	err := httpServer.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err) //nolint:revive // Toy code
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"common/input"
+	"common/logging"
+	"common/submit"
+
+	"github.com/alexflint/go-arg"
+)
+
+type Args struct {
+	Day     int    `arg:"positional,required"                                               help:"day of the puzzle"`
+	Part    int    `arg:"positional,required"                                               help:"part of the puzzle, 1 or 2"`
+	Answer  string `arg:"positional,required"                                               help:"answer to submit, or - to take it from the JSON report of a puzzle command on stdin"`
+	Key     string `arg:"--key"                                                             help:"answer to take from the report, when it has several"`
+	Session string `arg:"--session,env:AOC_SESSION"                                         help:"session cookie of the puzzle website"`
+	History string `arg:"--history"                                                         help:"file recording every submission (default: submissions.json in the input cache directory)"`
+	BaseURL string `arg:"--base-url"                    default:"https://adventofcode.com" help:"address of the puzzle website"`
+
+	logging.Flags
+}
+
+func main() {
+	var args Args
+	arg.MustParse(&args)
+	logging.Setup(args.Flags)
+
+	answer := args.Answer
+	if answer == "-" {
+		var err error
+		answer, err = submit.AnswerFromReport(os.Stdin, args.Key)
+		if err != nil {
+			log.Fatal(err) //nolint:revive // Toy code
+		}
+	}
+	if args.History == "" {
+		cacheDir, err := input.CacheDir()
+		if err != nil {
+			log.Fatal(err) //nolint:revive // Toy code
+		}
+		args.History = filepath.Join(cacheDir, "submissions.json")
+	}
+
+	client := submit.NewClient(args.Session, args.History)
+	client.BaseURL = args.BaseURL
+	response, err := client.Submit(context.Background(), args.Day, args.Part, answer)
+	if err != nil {
+		log.Fatal(err) //nolint:revive // Toy code
+	}
+
+	slog.Info("submitted", "day", args.Day, "part", args.Part, "answer", answer, "verdict", response.Verdict)
+	slog.Debug("website said", "message", response.Message)
+	if response.Wait > 0 {
+		slog.Info("wait before submitting again", "wait", response.Wait)
+	}
+	if response.Verdict != submit.Correct {
+		os.Exit(1) //nolint:revive // Toy code
+	}
+}